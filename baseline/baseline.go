@@ -0,0 +1,137 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// Version is the version of the baseline file format.
+const Version = 1
+
+// Baseline is a set of accepted findings. Findings in the baseline do not
+// fail a lint run; only new ones do.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"ruleId"`
+	File        string `json:"file,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Message     string `json:"message"`
+}
+
+// Fingerprint returns the result's fingerprint, computing it with
+// types.Fingerprint if it has not been set.
+func Fingerprint(result types.RuleFunctionResult) string {
+	if result.Fingerprint != "" {
+		return result.Fingerprint
+	}
+	return types.Fingerprint(result)
+}
+
+// New builds a baseline accepting every unsuppressed violation in results.
+// Rule execution errors are never baselined.
+func New(results []types.RuleFunctionResult) *Baseline {
+	b := &Baseline{Version: Version, Entries: []Entry{}}
+	seen := make(map[string]bool)
+
+	for _, result := range results {
+		if result.Suppressed || result.IsError() {
+			continue
+		}
+		fingerprint := Fingerprint(result)
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+
+		entry := Entry{
+			Fingerprint: fingerprint,
+			RuleID:      result.RuleID,
+			Message:     result.Message,
+		}
+		if result.File != "" {
+			entry.File = types.FingerprintFile(result.File)
+		}
+		if len(result.Path) > 0 {
+			entry.Path = types.JSONPath(result.Path)
+		}
+		b.Entries = append(b.Entries, entry)
+	}
+
+	sort.Slice(b.Entries, func(i, j int) bool {
+		if b.Entries[i].RuleID != b.Entries[j].RuleID {
+			return b.Entries[i].RuleID < b.Entries[j].RuleID
+		}
+		if b.Entries[i].Path != b.Entries[j].Path {
+			return b.Entries[i].Path < b.Entries[j].Path
+		}
+		return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint
+	})
+
+	return b
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading baseline file: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("error parsing baseline file: %w", err)
+	}
+	if b.Version != Version {
+		return nil, fmt.Errorf("unsupported baseline version %d", b.Version)
+	}
+
+	return &b, nil
+}
+
+// Save writes the baseline to path.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Apply marks every result found in the baseline as baselined and returns
+// the baseline entries that no longer match any result, i.e. findings that
+// have since been fixed.
+func (b *Baseline) Apply(results []types.RuleFunctionResult) []Entry {
+	entries := make(map[string]bool, len(b.Entries))
+	for _, entry := range b.Entries {
+		entries[entry.Fingerprint] = true
+	}
+
+	matched := make(map[string]bool)
+	for i := range results {
+		if results[i].IsError() {
+			continue
+		}
+		fingerprint := Fingerprint(results[i])
+		if entries[fingerprint] {
+			results[i].Baselined = true
+			matched[fingerprint] = true
+		}
+	}
+
+	var fixed []Entry
+	for _, entry := range b.Entries {
+		if !matched[entry.Fingerprint] {
+			fixed = append(fixed, entry)
+		}
+	}
+	return fixed
+}
@@ -0,0 +1,104 @@
+package baseline
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestFingerprint(t *testing.T) {
+	result := types.RuleFunctionResult{
+		RuleID:  "method-description",
+		File:    "openrpc.json",
+		Path:    []string{"methods", "0", "description"},
+		Message: "Missing required field 'description' at $.methods[0]",
+		Range:   &types.Range{Start: types.Position{Line: 3, Column: 5}, End: types.Position{Line: 3, Column: 20}},
+	}
+
+	if Fingerprint(result) != Fingerprint(result) {
+		t.Errorf("Expected fingerprint to be stable")
+	}
+
+	other := result
+	other.Path = []string{"methods", "1", "description"}
+	if Fingerprint(result) == Fingerprint(other) {
+		t.Errorf("Expected different paths to produce different fingerprints")
+	}
+
+	other = result
+	other.File = "other.json"
+	if Fingerprint(result) == Fingerprint(other) {
+		t.Errorf("Expected different files to produce different fingerprints")
+	}
+
+	// Flags set after linting must not change the fingerprint
+	suppressed := result
+	suppressed.Suppressed = true
+	if Fingerprint(result) != Fingerprint(suppressed) {
+		t.Errorf("Expected suppression not to affect the fingerprint")
+	}
+
+	// Nor may edits elsewhere in the file that move the finding
+	shifted := result
+	shifted.Range = &types.Range{Start: types.Position{Line: 7, Column: 5}, End: types.Position{Line: 7, Column: 20}}
+	if Fingerprint(result) != Fingerprint(shifted) {
+		t.Errorf("Expected line shifts not to affect the fingerprint")
+	}
+
+	normalised := result
+	normalised.File = "./openrpc.json"
+	normalised.Message = "  Missing required field 'description'\n at $.methods[0] "
+	if Fingerprint(result) != Fingerprint(normalised) {
+		t.Errorf("Expected the file and message to be normalised")
+	}
+
+	// The same file named by an absolute path, as on another checkout
+	absolute, err := filepath.Abs(result.File)
+	if err != nil {
+		t.Fatalf("Failed to make path absolute: %v", err)
+	}
+	moved := result
+	moved.File = absolute
+	if Fingerprint(result) != Fingerprint(moved) {
+		t.Errorf("Expected an absolute path to fingerprint like the relative one")
+	}
+
+	result.Fingerprint = "precomputed"
+	if Fingerprint(result) != "precomputed" {
+		t.Errorf("Expected the result's own fingerprint to be used")
+	}
+}
+
+func TestBaselineApply(t *testing.T) {
+	existing := types.RuleFunctionResult{RuleID: "rule-a", Path: []string{"info"}, Message: "existing"}
+	fixed := types.RuleFunctionResult{RuleID: "rule-b", Path: []string{"info"}, Message: "fixed"}
+	added := types.RuleFunctionResult{RuleID: "rule-c", Path: []string{"info"}, Message: "new"}
+	broken := types.RuleFunctionResult{RuleID: "rule-d", Message: "unknown function: nope", Kind: types.KindError}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := New([]types.RuleFunctionResult{existing, fixed, broken}).Save(path); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(b.Entries) != 2 {
+		t.Fatalf("Expected 2 baseline entries without the rule error, got %d", len(b.Entries))
+	}
+
+	results := []types.RuleFunctionResult{existing, added}
+	fixedEntries := b.Apply(results)
+
+	if !results[0].Baselined {
+		t.Errorf("Expected existing finding to be baselined")
+	}
+	if results[1].Baselined {
+		t.Errorf("Expected new finding not to be baselined")
+	}
+	if len(fixedEntries) != 1 || fixedEntries[0].RuleID != "rule-b" {
+		t.Errorf("Expected rule-b to be reported as fixed, got %+v", fixedEntries)
+	}
+}
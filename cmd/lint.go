@@ -2,47 +2,128 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/shanejonas/openrpc-linter/baseline"
 	"github.com/shanejonas/openrpc-linter/reporters"
 	"github.com/shanejonas/openrpc-linter/rules"
 	"github.com/shanejonas/openrpc-linter/types"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var (
-	rulesFile    string
-	outputFormat string
+	rulesFile      string
+	outputFormats  []string
+	baselineFile   string
+	updateBaseline bool
+	verbose        bool
+	concurrency    int
+	ruleTimeout    time.Duration
 )
 
-type RulesWrapper struct {
-	Rules map[string]types.Rule `yaml:"rules"`
+type LintOptions struct {
+	OpenRPCFile    string
+	RulesFile      string
+	Output         io.Writer
+	ErrOutput      io.Writer              // Notices that should not mix with the report, e.g. baseline updates
+	Format         string                 // Single report format written to Output; see Reports
+	Reports        []types.ReporterConfig // Reports to write, overriding Format and the ruleset's reporters section
+	Baseline       string
+	UpdateBaseline bool
+	Verbose        bool          // Write details such as skipped rules to ErrOutput
+	Concurrency    int           // Maximum number of rules run at once; defaults to the number of CPUs
+	RuleTimeout    time.Duration // Longest a single rule may run; zero means no limit
 }
 
-type LintOptions struct {
-	OpenRPCFile string
-	RulesFile   string
-	Output      io.Writer
-	Format      string
+// reportConfigs returns the reports to write: those in opts, falling back to
+// the ruleset's reporters section and then to text on Output.
+func reportConfigs(opts LintOptions, ruleset *types.Ruleset) ([]types.ReporterConfig, error) {
+	configs := opts.Reports
+	if len(configs) == 0 && opts.Format != "" {
+		configs = []types.ReporterConfig{{Format: opts.Format}}
+	}
+	if len(configs) == 0 {
+		configs = ruleset.Reporters
+	}
+	if len(configs) == 0 {
+		configs = []types.ReporterConfig{{Format: "text"}}
+	}
+
+	// Reports sharing an output would be concatenated into something no tool
+	// can read.
+	outputs := make(map[string]string)
+	for _, config := range configs {
+		if reporters.GetReporter(config.Format, nil) == nil {
+			return nil, fmt.Errorf("unknown report format %q (available: %s)", config.Format, strings.Join(reporters.ReporterNames(), ", "))
+		}
+		output := outputKey(config.Output)
+		if other, ok := outputs[output]; ok {
+			if config.Output == "" {
+				return nil, fmt.Errorf("%s and %s reports both write to stdout; give all but one an output file, e.g. --format %s=report.out", other, config.Format, config.Format)
+			}
+			return nil, fmt.Errorf("%s and %s reports both write to %s", other, config.Format, config.Output)
+		}
+		outputs[output] = config.Format
+	}
+	return configs, nil
 }
 
-func GetReporter(format string) reporters.Reporter {
-	switch format {
-	case "json":
-		return &reporters.JSONReporter{}
-	case "text":
-		return &reporters.TextReporter{}
-	default:
-		return &reporters.TextReporter{}
+// outputKey identifies a report output, so that different spellings of the
+// same file, e.g. out.txt and ./out.txt, are the same output. Stdout is "".
+func outputKey(output string) string {
+	if output == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(output); err == nil {
+		return abs
+	}
+	return filepath.Clean(output)
+}
+
+// writeReports writes every configured report, to its file or to Output.
+func writeReports(opts LintOptions, configs []types.ReporterConfig, run *reporters.Run, results []types.RuleFunctionResult, totalRules int) error {
+	for _, config := range configs {
+		reporter := reporters.GetReporter(config.Format, run)
+		if config.Output == "" {
+			if err := reporter.Format(results, totalRules, opts.Output); err != nil {
+				return err
+			}
+			continue
+		}
+
+		f, err := os.Create(config.Output)
+		if err != nil {
+			return fmt.Errorf("error creating %s report: %w", config.Format, err)
+		}
+		if err := reporter.Format(results, totalRules, f); err != nil {
+			f.Close()
+			return fmt.Errorf("error writing %s report: %w", config.Format, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("error writing %s report: %w", config.Format, err)
+		}
+		if opts.Verbose {
+			fmt.Fprintf(opts.ErrOutput, "Wrote %s report to %s\n", config.Format, config.Output)
+		}
 	}
+	return nil
 }
 
 func RunLint(opts LintOptions) error {
+	if opts.ErrOutput == nil {
+		opts.ErrOutput = io.Discard
+	}
+
 	openrpcData, err := os.ReadFile(opts.OpenRPCFile)
 	if err != nil {
 		fmt.Fprintf(opts.Output, "Error reading OpenRPC file: %v\n", err)
@@ -57,184 +138,322 @@ func RunLint(opts LintOptions) error {
 	}
 
 	// Resolve all $ref references in the document
-	resolvedDoc, err := resolveRefs(openrpcDoc)
+	resolvedDoc, refOrigins, err := rules.ResolveRefs(openrpcDoc)
 	if err != nil {
 		fmt.Fprintf(opts.Output, "Error resolving $refs in OpenRPC file: %v\n", err)
 		return err
 	}
 
-	// get the rules from file
-	rawRules, err := os.ReadFile(opts.RulesFile)
+	// load the rules, along with any rulesets they extend
+	ruleset, err := rules.LoadRuleset(opts.RulesFile)
 	if err != nil {
-		fmt.Fprintf(opts.Output, "Error reading rules file: %v\n", err)
+		fmt.Fprintf(opts.Output, "Error loading rules file: %v\n", err)
 		return err
 	}
 
-	// parse the rules
-	var rulesWrapper RulesWrapper
-	err = yaml.Unmarshal(rawRules, &rulesWrapper)
+	reports, err := reportConfigs(opts, ruleset)
 	if err != nil {
-		fmt.Fprintf(opts.Output, "Error parsing rules file: %v\n", err)
+		fmt.Fprintf(opts.Output, "Error: %v\n", err)
+		return err
+	}
+	if opts.UpdateBaseline && opts.Baseline == "" {
+		err := fmt.Errorf("--update-baseline requires --baseline")
+		fmt.Fprintf(opts.Output, "Error: %v\n", err)
 		return err
 	}
 
 	var allResults []types.RuleFunctionResult
-	totalRules := len(rulesWrapper.Rules)
+	var ruleIds []string
+	applicableRules := make(map[string]types.Rule)
 
-	for ruleId, rule := range rulesWrapper.Rules {
-		context := types.RuleFunctionContext{
-			Rule:             &rule,
-			RuleID:           ruleId,
-			Document:         openrpcDoc,
-			ResolvedDocument: resolvedDoc,
+	fileRules := rules.RulesForFile(ruleset, opts.OpenRPCFile)
+	for _, ruleId := range sortedRuleIds(fileRules) {
+		rule := fileRules[ruleId]
+		if rule.Severity == types.SeverityOff {
+			continue
 		}
-		results, err := rules.ExecuteRule(&rule, context)
 
+		matches, err := rules.MatchesFormats(&rule, openrpcDoc)
 		if err != nil {
+			ruleIds = append(ruleIds, ruleId)
 			allResults = append(allResults, types.RuleFunctionResult{
-				RuleID:  ruleId,
-				Message: err.Error(),
+				RuleID:   ruleId,
+				Message:  err.Error(),
+				Severity: types.SeverityError,
+				Kind:     types.KindError,
 			})
 			continue
 		}
+		if !matches {
+			if opts.Verbose {
+				fmt.Fprintf(opts.ErrOutput, "Skipping rule %s: document does not match formats %s\n", ruleId, strings.Join(rule.Formats, ", "))
+			}
+			continue
+		}
 
-		allResults = append(allResults, results...)
+		ruleIds = append(ruleIds, ruleId)
+		applicableRules[ruleId] = rule
+	}
+	totalRules := len(ruleIds)
+
+	context := types.RuleFunctionContext{
+		Document:         openrpcDoc,
+		ResolvedDocument: resolvedDoc,
+		RefOrigins:       refOrigins,
+	}
+	allResults = append(allResults, runRules(applicableRules, context, opts)...)
+	rules.SortResults(allResults)
+
+	allResults, err = rules.ApplyOverrides(ruleset, opts.OpenRPCFile, openrpcDoc, allResults)
+	if err != nil {
+		fmt.Fprintf(opts.Output, "Error applying overrides: %v\n", err)
+		return err
+	}
+
+	sourceMap, err := rules.NewSourceMap(openrpcData)
+	if err != nil {
+		fmt.Fprintf(opts.Output, "Error parsing OpenRPC file: %v\n", err)
+		return err
+	}
+	rules.LocateResults(allResults, opts.OpenRPCFile, sourceMap)
+	for i := range allResults {
+		allResults[i].Fingerprint = types.Fingerprint(allResults[i])
 	}
 
-	errorCount := len(allResults)
+	if err := applyBaseline(opts, allResults); err != nil {
+		fmt.Fprintf(opts.Output, "Error applying baseline: %v\n", err)
+		return err
+	}
+
+	errorCount := 0
+	ruleErrorCount := 0
+	for _, result := range allResults {
+		if result.IsError() {
+			ruleErrorCount++
+		} else if !result.Suppressed && !result.Baselined && result.Severity == types.SeverityError {
+			errorCount++
+		}
+	}
 
-	reporter := GetReporter(opts.Format)
-	if err := reporter.Format(allResults, totalRules, opts.Output); err != nil {
+	run := &reporters.Run{
+		ToolVersion: Version,
+		RulesFile:   opts.RulesFile,
+		File:        opts.OpenRPCFile,
+		Source:      openrpcData,
+		Document:    openrpcDoc,
+		Rules:       applicableRules,
+	}
+	if err := writeReports(opts, reports, run, allResults, totalRules); err != nil {
+		fmt.Fprintf(opts.Output, "Error: %v\n", err)
 		return err
 	}
 
+	if ruleErrorCount > 0 {
+		return &RuleErrors{Count: ruleErrorCount}
+	}
 	if errorCount > 0 {
-		return fmt.Errorf("found %d linting error(s)", errorCount)
+		return &LintErrors{Count: errorCount}
 	}
 
 	return nil
 }
 
-var lintCmd = &cobra.Command{
-	Use:   "lint [openrpc-file]",
-	Short: "Lint an OpenRPC document",
-	Long:  "Lint an OpenRPC document for compliance with OpenRPC specification",
-	Args:  cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		openrpcFile := "openrpc.json"
-		if len(args) > 0 {
-			openrpcFile = args[0]
-		}
+// Exit codes for the lint command.
+const (
+	ExitOK            = 0
+	ExitLintErrors    = 1 // The document has lint errors
+	ExitMisconfigured = 2 // The linter could not run, e.g. a rule failed or a file could not be read
+)
 
-		opts := LintOptions{
-			OpenRPCFile: openrpcFile,
-			RulesFile:   rulesFile,
-			Output:      cmd.OutOrStdout(),
-			Format:      outputFormat,
-		}
+// LintErrors is returned by RunLint when the document has lint errors.
+type LintErrors struct {
+	Count int
+}
 
-		if err := RunLint(opts); err != nil {
-			os.Exit(1)
-		}
-	},
+func (e *LintErrors) Error() string {
+	return fmt.Sprintf("found %d linting error(s)", e.Count)
 }
 
-func init() {
-	lintCmd.Flags().StringVarP(&rulesFile, "rules", "r", "", "Path to rules YAML file")
-	lintCmd.Flags().StringVarP(&outputFormat, "format", "f", "text", "Output format (text, json)")
-	rootCmd.AddCommand(lintCmd)
+// RuleErrors is returned by RunLint when one or more rules failed to run.
+// It takes precedence over LintErrors, since the findings of a run with
+// broken rules are incomplete.
+type RuleErrors struct {
+	Count int
 }
 
-// resolveRefs resolves all $ref references in the document
-func resolveRefs(document interface{}) (interface{}, error) {
-	// For now, we'll implement a basic $ref resolver that handles internal references
-	// This is a simplified implementation that can be enhanced later
+func (e *RuleErrors) Error() string {
+	return fmt.Sprintf("%d rule(s) failed to run", e.Count)
+}
 
-	docBytes, err := json.Marshal(document)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal document: %w", err)
+// ExitCode maps an error returned by RunLint to the lint command's exit
+// code. Anything other than lint errors means the linter is misconfigured.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
 	}
-
-	var resolved interface{}
-	err = json.Unmarshal(docBytes, &resolved)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal document: %w", err)
+	var lintErrors *LintErrors
+	if errors.As(err, &lintErrors) {
+		return ExitLintErrors
 	}
-
-	// Recursively resolve $refs within the document
-	resolved = resolveRefsRecursive(resolved, document)
-
-	return resolved, nil
+	return ExitMisconfigured
 }
 
-// resolveRefsRecursive recursively resolves $ref references in the document
-func resolveRefsRecursive(current interface{}, root interface{}) interface{} {
-	switch v := current.(type) {
-	case map[string]interface{}:
-		// Check if this is a $ref
-		if ref, exists := v["$ref"]; exists {
-			if refStr, ok := ref.(string); ok {
-				// Handle internal refs (starting with #)
-				if strings.HasPrefix(refStr, "#/") {
-					resolved := resolveJSONPointer(refStr[2:], root) // Remove the "#/" prefix
-					if resolved != nil {
-						return resolveRefsRecursive(resolved, root)
-					}
+// runRules executes rules on a bounded pool of workers. Each rule gets its
+// own copy of the context; the documents in it are shared and read-only, as
+// are the JSONPath query results cached for them.
+// Results are returned in rule ID order regardless of completion order. A
+// rule that fails, panics or times out is reported as a single result of
+// kind error without stopping the others; in verbose mode the stack of a panic is
+// written to ErrOutput.
+func runRules(ruleMap map[string]types.Rule, context types.RuleFunctionContext, opts LintOptions) []types.RuleFunctionResult {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+
+	ruleIds := sortedRuleIds(ruleMap)
+	ruleResults := make([][]types.RuleFunctionResult, len(ruleIds))
+	ruleErrors := make([]error, len(ruleIds))
+	cache := rules.NewQueryCache()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(ruleIds); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ruleId := ruleIds[i]
+				rule := ruleMap[ruleId]
+
+				ruleContext := context
+				ruleContext.Rule = &rule
+				ruleContext.RuleID = ruleId
+
+				results, err := rules.ExecuteRuleIsolated(&rule, ruleContext, cache, opts.RuleTimeout)
+				if err != nil {
+					results = []types.RuleFunctionResult{{
+						RuleID:   ruleId,
+						Message:  err.Error(),
+						Severity: types.SeverityError,
+						Kind:     types.KindError,
+					}}
 				}
+				ruleResults[i] = results
+				ruleErrors[i] = err
 			}
-			// If we can't resolve the ref, return the original $ref
-			return v
-		}
+		}()
+	}
 
-		// Recursively process all values in the map
-		result := make(map[string]interface{})
-		for key, value := range v {
-			result[key] = resolveRefsRecursive(value, root)
-		}
-		return result
+	for i := range ruleIds {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-	case []interface{}:
-		// Recursively process all items in the array
-		result := make([]interface{}, len(v))
-		for i, item := range v {
-			result[i] = resolveRefsRecursive(item, root)
+	var allResults []types.RuleFunctionResult
+	for i, results := range ruleResults {
+		allResults = append(allResults, results...)
+
+		var execErr *rules.RuleExecutionError
+		if opts.Verbose && errors.As(ruleErrors[i], &execErr) && execErr.Stack != nil {
+			fmt.Fprintf(opts.ErrOutput, "%s\n%s\n", execErr, execErr.Stack)
 		}
-		return result
+	}
+	return allResults
+}
 
-	default:
-		// For primitive types, return as-is
-		return v
+func sortedRuleIds(ruleMap map[string]types.Rule) []string {
+	ruleIds := make([]string, 0, len(ruleMap))
+	for ruleId := range ruleMap {
+		ruleIds = append(ruleIds, ruleId)
 	}
+	sort.Strings(ruleIds)
+	return ruleIds
 }
 
-// resolveJSONPointer resolves a JSON pointer path in the document
-func resolveJSONPointer(path string, document interface{}) interface{} {
-	if path == "" {
-		return document
+// applyBaseline marks results accepted by the baseline file, first rewriting
+// the baseline from the current results when UpdateBaseline is set.
+func applyBaseline(opts LintOptions, results []types.RuleFunctionResult) error {
+	if opts.Baseline == "" {
+		return nil
 	}
 
-	parts := strings.Split(path, "/")
-	current := document
+	if opts.UpdateBaseline {
+		b := baseline.New(results)
+		if err := b.Save(opts.Baseline); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.ErrOutput, "Wrote %d finding(s) to baseline %s\n", len(b.Entries), opts.Baseline)
+	}
 
-	for _, part := range parts {
-		// Unescape JSON pointer characters
-		part = strings.ReplaceAll(part, "~1", "/")
-		part = strings.ReplaceAll(part, "~0", "~")
+	b, err := baseline.Load(opts.Baseline)
+	if err != nil {
+		return err
+	}
 
-		switch v := current.(type) {
-		case map[string]interface{}:
-			if val, exists := v[part]; exists {
-				current = val
-			} else {
-				return nil // Path not found
-			}
-		case []interface{}:
-			// Handle array indices (not commonly used in OpenRPC, but for completeness)
-			return nil
-		default:
-			return nil // Can't traverse further
+	fixed := b.Apply(results)
+	if len(fixed) > 0 {
+		fmt.Fprintf(opts.ErrOutput, "%d baselined finding(s) have been fixed and can be removed from %s:\n", len(fixed), opts.Baseline)
+		for _, entry := range fixed {
+			fmt.Fprintf(opts.ErrOutput, "  - %s: %s\n", entry.RuleID, entry.Message)
 		}
 	}
 
-	return current
+	return nil
+}
+
+// reportFlags parses --format values of the form name[=path].
+func reportFlags(values []string) []types.ReporterConfig {
+	var configs []types.ReporterConfig
+	for _, value := range values {
+		format, output, _ := strings.Cut(value, "=")
+		configs = append(configs, types.ReporterConfig{Format: format, Output: output})
+	}
+	return configs
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [openrpc-file]",
+	Short: "Lint an OpenRPC document",
+	Long: `Lint an OpenRPC document for compliance with OpenRPC specification
+
+Exit status is 0 when no errors are found, 1 when the document has lint
+errors, and 2 when the linter could not run, e.g. a rule failed to execute or
+a file could not be read.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		openrpcFile := "openrpc.json"
+		if len(args) > 0 {
+			openrpcFile = args[0]
+		}
+
+		opts := LintOptions{
+			OpenRPCFile:    openrpcFile,
+			RulesFile:      rulesFile,
+			Output:         cmd.OutOrStdout(),
+			ErrOutput:      cmd.ErrOrStderr(),
+			Reports:        reportFlags(outputFormats),
+			Baseline:       baselineFile,
+			UpdateBaseline: updateBaseline,
+			Verbose:        verbose,
+			Concurrency:    concurrency,
+			RuleTimeout:    ruleTimeout,
+		}
+
+		if err := RunLint(opts); err != nil {
+			os.Exit(ExitCode(err))
+		}
+	},
+}
+
+func init() {
+	lintCmd.Flags().StringVarP(&rulesFile, "rules", "r", "", "Path to rules YAML file")
+	lintCmd.Flags().StringArrayVarP(&outputFormats, "format", "f", nil, "Report format, optionally written to a file as name=path; may be repeated (default text)\nFormats: "+strings.Join(reporters.ReporterNames(), ", "))
+	lintCmd.Flags().StringVar(&baselineFile, "baseline", "", "Path to a baseline file of accepted findings")
+	lintCmd.Flags().BoolVar(&updateBaseline, "update-baseline", false, "Write the current findings to the baseline file")
+	lintCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Print details such as skipped rules to stderr")
+	lintCmd.Flags().IntVar(&concurrency, "concurrency", 0, "Maximum number of rules to run at once (default: number of CPUs)")
+	lintCmd.Flags().DurationVar(&ruleTimeout, "rule-timeout", 30*time.Second, "Maximum time a single rule may run, e.g. 10s (0 for no limit)")
+	rootCmd.AddCommand(lintCmd)
 }
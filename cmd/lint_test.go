@@ -3,9 +3,19 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/shanejonas/openrpc-linter/functions"
+	"github.com/shanejonas/openrpc-linter/reporters"
+	"github.com/shanejonas/openrpc-linter/types"
 )
 
 func TestRunLint(t *testing.T) {
@@ -73,6 +83,9 @@ rules:
 	if !strings.Contains(err.Error(), "linting error(s)") {
 		t.Fatalf("Expected linting error, but got: %v", err)
 	}
+	if code := ExitCode(err); code != ExitLintErrors {
+		t.Fatalf("Expected exit code %d for linting errors, got %d", ExitLintErrors, code)
+	}
 
 	outputStr := output.String()
 	t.Logf("Lint output:\n%s", outputStr)
@@ -163,3 +176,834 @@ rules:
 		t.Errorf("Expected 'All 1 rules passed' in output, but got: %s", outputStr)
 	}
 }
+
+func TestRunLintCollapsesSharedRefs(t *testing.T) {
+	openrpcContent := `{
+		"openrpc": "1.2.6",
+		"info": {"title": "Test API", "version": "1.0.0"},
+		"methods": [
+			{"name": "foo", "params": [{"$ref": "#/components/contentDescriptors/Shared"}]},
+			{"name": "bar", "params": [{"$ref": "#/components/contentDescriptors/Shared"}]}
+		],
+		"components": {
+			"contentDescriptors": {
+				"Shared": {"name": "shared", "schema": {"type": "string"}}
+			}
+		}
+	}`
+
+	tempOpenRPC, err := os.CreateTemp("", "test-openrpc-*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp OpenRPC file: %v", err)
+	}
+	defer os.Remove(tempOpenRPC.Name())
+
+	if _, err := tempOpenRPC.WriteString(openrpcContent); err != nil {
+		t.Fatalf("Failed to write test OpenRPC file: %v", err)
+	}
+	tempOpenRPC.Close()
+
+	rulesContent := `rules:
+  param-description:
+    description: "Params must have description"
+    given: "$.methods[*].params[*]"
+    then:
+      field: "description"
+      function: "truthy"
+`
+
+	tempRules, err := os.CreateTemp("", "test-rules-*.yml")
+	if err != nil {
+		t.Fatalf("Failed to create temp rules file: %v", err)
+	}
+	defer os.Remove(tempRules.Name())
+
+	if _, err := tempRules.WriteString(rulesContent); err != nil {
+		t.Fatalf("Failed to write test rules file: %v", err)
+	}
+	tempRules.Close()
+
+	var output bytes.Buffer
+	opts := LintOptions{
+		OpenRPCFile: tempOpenRPC.Name(),
+		RulesFile:   tempRules.Name(),
+		Output:      &output,
+		Format:      "json",
+	}
+
+	if err := RunLint(opts); err == nil {
+		t.Fatalf("Expected RunLint to return error for linting violations, but got nil")
+	}
+
+	var report reporters.JSONReport
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output.String())
+	}
+
+	if len(report.Files) != 1 || len(report.Files[0].Results) != 1 {
+		t.Fatalf("Expected 1 collapsed result, got: %s", output.String())
+	}
+	results := report.Files[0].Results
+
+	expectedPath := []string{"components", "contentDescriptors", "Shared", "description"}
+	if !reflect.DeepEqual(results[0].Path, expectedPath) {
+		t.Errorf("Expected path %v, got %v", expectedPath, results[0].Path)
+	}
+
+	if len(results[0].Related) != 2 {
+		t.Errorf("Expected 2 related usage sites, got %v", results[0].Related)
+	}
+
+	// A definition used only from inside another shared definition is
+	// related to where the outer definition is used.
+	nestedFile := writeTempFile(t, "test-openrpc-*.json", `{
+		"openrpc": "1.2.6",
+		"info": {"title": "Test API", "version": "1.0.0"},
+		"methods": [
+			{"name": "foo", "params": [{"$ref": "#/components/contentDescriptors/P"}]},
+			{"name": "bar", "params": [{"$ref": "#/components/contentDescriptors/P"}]}
+		],
+		"components": {
+			"contentDescriptors": {"P": {"name": "p", "schema": {"$ref": "#/components/schemas/S"}}},
+			"schemas": {"S": {"type": "string"}}
+		}
+	}`)
+	nestedRules := writeTempFile(t, "test-rules-*.yml", `rules:
+  schema-title:
+    given: "$.methods[*].params[*].schema"
+    then:
+      field: "title"
+      function: "truthy"
+`)
+
+	output.Reset()
+	opts = LintOptions{
+		OpenRPCFile: nestedFile,
+		RulesFile:   nestedRules,
+		Output:      &output,
+		Format:      "json",
+	}
+	if err := RunLint(opts); ExitCode(err) != ExitLintErrors {
+		t.Fatalf("Expected lint errors exit code, got: %v", err)
+	}
+
+	report = reporters.JSONReport{}
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output.String())
+	}
+	if len(report.Files) != 1 || len(report.Files[0].Results) != 1 {
+		t.Fatalf("Expected 1 collapsed result, got: %s", output.String())
+	}
+	nested := report.Files[0].Results[0]
+
+	if nested.JSONPath != "$.components.schemas.S.title" {
+		t.Errorf("Expected the result at $.components.schemas.S.title, got %s", nested.JSONPath)
+	}
+	expectedRelated := []string{"$.methods[0].params[0].schema.title", "$.methods[1].params[0].schema.title"}
+	if !reflect.DeepEqual(nested.Related, expectedRelated) {
+		t.Errorf("Expected related usage sites %v, got %v", expectedRelated, nested.Related)
+	}
+}
+
+func TestRunLintSuppressed(t *testing.T) {
+	openrpcContent := `{
+		"info": {
+			"title": "Test API",
+			"version": "1.0.0",
+			"x-lint-ignore": ["info-description"]
+		}
+	}`
+
+	tempOpenRPC, err := os.CreateTemp("", "test-openrpc-*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp OpenRPC file: %v", err)
+	}
+	defer os.Remove(tempOpenRPC.Name())
+
+	if _, err := tempOpenRPC.WriteString(openrpcContent); err != nil {
+		t.Fatalf("Failed to write test OpenRPC file: %v", err)
+	}
+	tempOpenRPC.Close()
+
+	rulesContent := `rules:
+  info-description:
+    description: "Info must have description"
+    given: "$.info"
+    then:
+      field: "description"
+      function: "truthy"
+`
+
+	tempRules, err := os.CreateTemp("", "test-rules-*.yml")
+	if err != nil {
+		t.Fatalf("Failed to create temp rules file: %v", err)
+	}
+	defer os.Remove(tempRules.Name())
+
+	if _, err := tempRules.WriteString(rulesContent); err != nil {
+		t.Fatalf("Failed to write test rules file: %v", err)
+	}
+	tempRules.Close()
+
+	var output bytes.Buffer
+	opts := LintOptions{
+		OpenRPCFile: tempOpenRPC.Name(),
+		RulesFile:   tempRules.Name(),
+		Output:      &output,
+	}
+
+	if err := RunLint(opts); err != nil {
+		t.Fatalf("RunLint should succeed when all violations are suppressed, but got: %v", err)
+	}
+
+	outputStr := output.String()
+	if !strings.Contains(outputStr, "All 1 rules passed! (1 suppressed)") {
+		t.Errorf("Expected suppressed count in output, but got: %s", outputStr)
+	}
+
+	// An ignore on a method covers the shared definitions it uses
+	refOpenRPC := writeTempFile(t, "test-openrpc-*.json", `{
+		"info": {"title": "Test API", "version": "1.0.0"},
+		"methods": [{
+			"name": "foo",
+			"x-lint-ignore": "schema-title",
+			"params": [{"name": "bar", "schema": {"$ref": "#/components/schemas/Foo"}}]
+		}],
+		"components": {"schemas": {"Foo": {"type": "string"}}}
+	}`)
+	refRules := writeTempFile(t, "test-rules-*.yml", `rules:
+  schema-title:
+    given: "$.methods[*].params[*].schema"
+    then:
+      field: "title"
+      function: "truthy"
+`)
+
+	output.Reset()
+	opts = LintOptions{
+		OpenRPCFile: refOpenRPC,
+		RulesFile:   refRules,
+		Output:      &output,
+	}
+	if err := RunLint(opts); err != nil {
+		t.Fatalf("RunLint should succeed when a $ref'd finding is suppressed, but got: %v\n%s", err, output.String())
+	}
+	if !strings.Contains(output.String(), "All 1 rules passed! (1 suppressed)") {
+		t.Errorf("Expected suppressed count in output, but got: %s", output.String())
+	}
+}
+
+// writeTempFile writes content to a new temp file and returns its path
+func writeTempFile(t *testing.T, pattern string, content string) string {
+	t.Helper()
+	file, err := os.CreateTemp(t.TempDir(), pattern)
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	return file.Name()
+}
+
+func TestRunLintOverrides(t *testing.T) {
+	openrpcFile := writeTempFile(t, "experimental-*.json", `{
+		"info": {"title": "Test API", "version": "1.0.0"},
+		"methods": [{"name": "foo"}]
+	}`)
+
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  method-description:
+    given: "$.methods[*]"
+    then:
+      field: "description"
+      function: "truthy"
+  method-examples:
+    given: "$.methods[*]"
+    then:
+      field: "examples"
+      function: "truthy"
+overrides:
+  - files: ["experimental-*.json"]
+    rules:
+      method-description: warn
+      method-examples: "off"
+`)
+
+	var output bytes.Buffer
+	opts := LintOptions{
+		OpenRPCFile: openrpcFile,
+		RulesFile:   rulesFile,
+		Output:      &output,
+	}
+
+	if err := RunLint(opts); err != nil {
+		t.Fatalf("RunLint should succeed when only warnings are found, but got: %v", err)
+	}
+
+	outputStr := output.String()
+	if !strings.Contains(outputStr, "1 warning(s) found in 1 rules") {
+		t.Errorf("Expected warning summary in output, but got: %s", outputStr)
+	}
+	if strings.Contains(outputStr, "method-examples") {
+		t.Errorf("Expected method-examples to be switched off, but got: %s", outputStr)
+	}
+}
+
+func TestRunLintConcurrencyDeterministic(t *testing.T) {
+	openrpcFile := writeTempFile(t, "test-openrpc-*.json", `{
+		"info": {"title": "Test API", "version": "1.0.0"},
+		"methods": [{"name": "a"}, {"name": "b"}, {"name": "c"}],
+		"components": {"schemas": {"A": {}, "B": {}, "C": {}}}
+	}`)
+
+	rulesContent := "rules:\n"
+	for _, field := range []string{"description", "summary", "errors", "examples", "result", "tags"} {
+		rulesContent += `  method-` + field + `:
+    given: "$.methods[*]"
+    then:
+      field: "` + field + `"
+      function: "truthy"
+  schema-` + field + `:
+    given: "$.components.schemas[*]"
+    then:
+      field: "` + field + `"
+      function: "truthy"
+`
+	}
+	rulesFile := writeTempFile(t, "test-rules-*.yml", rulesContent)
+
+	var expected string
+	for i, concurrency := range []int{1, 8, 8, 8} {
+		var output bytes.Buffer
+		opts := LintOptions{
+			OpenRPCFile: openrpcFile,
+			RulesFile:   rulesFile,
+			Output:      &output,
+			Format:      "json",
+			Concurrency: concurrency,
+		}
+
+		if err := RunLint(opts); err == nil {
+			t.Fatalf("Expected RunLint to return error for linting violations, but got nil")
+		}
+
+		if i == 0 {
+			expected = output.String()
+			continue
+		}
+		if output.String() != expected {
+			t.Fatalf("Expected output with concurrency %d to match sequential output.\nExpected:\n%s\nGot:\n%s", concurrency, expected, output.String())
+		}
+	}
+}
+
+type panickingRule struct{}
+
+func (r *panickingRule) RunRule(value interface{}, context types.RuleFunctionContext) []types.RuleFunctionResult {
+	_ = value.(map[string]interface{})["missing"].(string)
+	return nil
+}
+
+func (r *panickingRule) GetSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{}
+}
+
+type blockingRule struct {
+	release chan struct{}
+}
+
+func (r *blockingRule) RunRule(value interface{}, context types.RuleFunctionContext) []types.RuleFunctionResult {
+	<-r.release
+	return nil
+}
+
+func (r *blockingRule) GetSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{}
+}
+
+func TestRunLintIsolatesRuleFailures(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	functions.RegisterFunction("test-panic", &panickingRule{})
+	functions.RegisterFunction("test-block", &blockingRule{release: release})
+
+	openrpcFile := writeTempFile(t, "test-openrpc-*.json", `{
+		"info": {"title": "Test API", "version": "1.0.0"}
+	}`)
+
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  a-panics:
+    given: "$.info"
+    then:
+      function: "test-panic"
+  b-blocks:
+    given: "$.info"
+    then:
+      function: "test-block"
+  c-info-description:
+    given: "$.info"
+    then:
+      field: "description"
+      function: "truthy"
+`)
+
+	var output, errOutput bytes.Buffer
+	opts := LintOptions{
+		OpenRPCFile: openrpcFile,
+		RulesFile:   rulesFile,
+		Output:      &output,
+		ErrOutput:   &errOutput,
+		Verbose:     true,
+		RuleTimeout: 50 * time.Millisecond,
+	}
+
+	err := RunLint(opts)
+	if code := ExitCode(err); code != ExitMisconfigured {
+		t.Fatalf("Expected exit code %d for failed rules, got %d (%v)", ExitMisconfigured, code, err)
+	}
+
+	outputStr := output.String()
+	for _, expected := range []string{
+		"a-panics: rule a-panics panicked: interface conversion",
+		"b-blocks: rule b-blocks timed out after 50ms",
+		"c-info-description: Missing required field 'description' at $.info",
+		"1 error(s) found in 1 rules",
+		"2 of 3 rules failed to run",
+	} {
+		if !strings.Contains(outputStr, expected) {
+			t.Errorf("Expected %q in output, but got: %s", expected, outputStr)
+		}
+	}
+
+	if !strings.Contains(errOutput.String(), "goroutine") {
+		t.Errorf("Expected panic stack in verbose output, but got: %s", errOutput.String())
+	}
+}
+
+// TestRunLintReports is a smoke test that every registered format is wired
+// through RunLint; the reporters package tests each format's output.
+func TestRunLintReports(t *testing.T) {
+	openrpcFile := writeTempFile(t, "test-openrpc-*.json", `{
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "methods": [
+    {"name": "foo"}
+  ]
+}`)
+
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  method-description:
+    given: "$.methods[*]"
+    then:
+      field: "description"
+      function: "truthy"
+`)
+
+	for _, format := range reporters.ReporterNames() {
+		t.Run(format, func(t *testing.T) {
+			var output bytes.Buffer
+			opts := LintOptions{
+				OpenRPCFile: openrpcFile,
+				RulesFile:   rulesFile,
+				Output:      &output,
+				Format:      format,
+			}
+			if err := RunLint(opts); ExitCode(err) != ExitLintErrors {
+				t.Fatalf("Expected lint errors exit code, got: %v", err)
+			}
+			if !strings.Contains(output.String(), "method-description") {
+				t.Errorf("Expected the failing rule in the output, got: %s", output.String())
+			}
+		})
+	}
+}
+
+func TestRunLintBaseline(t *testing.T) {
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  method-description:
+    given: "$.methods[*]"
+    then:
+      field: "description"
+      function: "truthy"
+`)
+	dir := t.TempDir()
+	openrpcFile := filepath.Join(dir, "openrpc.json")
+	baselineFile := filepath.Join(dir, "baseline.json")
+
+	// Baseline entries are matched by file, so every run lints the same file
+	lint := func(openrpc string, update bool) (string, string, error) {
+		t.Helper()
+		if err := os.WriteFile(openrpcFile, []byte(openrpc), 0644); err != nil {
+			t.Fatalf("Failed to write test OpenRPC file: %v", err)
+		}
+		var output, errOutput bytes.Buffer
+		err := RunLint(LintOptions{
+			OpenRPCFile:    openrpcFile,
+			RulesFile:      rulesFile,
+			Output:         &output,
+			ErrOutput:      &errOutput,
+			Baseline:       baselineFile,
+			UpdateBaseline: update,
+		})
+		return output.String(), errOutput.String(), err
+	}
+
+	// Writing the baseline accepts the current findings
+	_, errOutput, err := lint(`{"methods": [{"name": "foo"}, {"name": "bar"}]}`, true)
+	if err != nil {
+		t.Fatalf("Expected the run updating the baseline to pass, got: %v", err)
+	}
+	if !strings.Contains(errOutput, "Wrote 2 finding(s) to baseline "+baselineFile) {
+		t.Errorf("Expected a notice about the baseline update, got: %s", errOutput)
+	}
+	if _, err := os.Stat(baselineFile); err != nil {
+		t.Fatalf("Expected the baseline file to be written: %v", err)
+	}
+
+	if _, _, err := lint(`{"methods": [{"name": "foo"}, {"name": "bar"}]}`, false); err != nil {
+		t.Errorf("Expected baselined findings to pass, got: %v", err)
+	}
+
+	// A new finding still fails, and fixed ones are listed
+	output, errOutput, err := lint(`{"methods": [{"name": "foo"}, {"name": "bar", "description": "Bar"}, {"name": "baz"}]}`, false)
+	if ExitCode(err) != ExitLintErrors {
+		t.Fatalf("Expected lint errors exit code for the new finding, got: %v", err)
+	}
+	if !strings.Contains(output, "$.methods[2]") || strings.Contains(output, "$.methods[0]") {
+		t.Errorf("Expected only the new finding to be reported, got: %s", output)
+	}
+	expected := "1 baselined finding(s) have been fixed and can be removed from " + baselineFile + ":\n" +
+		"  - method-description: Missing required field 'description' at $.methods[1]\n"
+	if !strings.Contains(errOutput, expected) {
+		t.Errorf("Expected the fixed finding to be listed:\n%s\nGot:\n%s", expected, errOutput)
+	}
+}
+
+func TestRunLintUpdateBaselineRequiresBaseline(t *testing.T) {
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  openrpc-13-only:
+    formats: ["openrpc-1.3"]
+    given: "$.methods[*]"
+    then:
+      field: "description"
+      function: "truthy"
+`)
+
+	var output, errOutput bytes.Buffer
+	err := RunLint(LintOptions{
+		OpenRPCFile:    writeTempFile(t, "test-openrpc-*.json", `{"openrpc": "1.2.6", "methods": []}`),
+		RulesFile:      rulesFile,
+		Output:         &output,
+		ErrOutput:      &errOutput,
+		UpdateBaseline: true,
+		Verbose:        true,
+	})
+	if ExitCode(err) != ExitMisconfigured || !strings.Contains(output.String(), "Error: --update-baseline requires --baseline") {
+		t.Fatalf("Expected the missing baseline to be reported, got: %v\n%s", err, output.String())
+	}
+	// Checked before rules are even selected
+	if errOutput.Len() != 0 {
+		t.Errorf("Expected no rules to be considered, got: %s", errOutput.String())
+	}
+}
+
+type countingReporter struct{}
+
+func (r *countingReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	_, err := fmt.Fprintf(output, "%d result(s) from %d rule(s)\n", len(results), totalRules)
+	return err
+}
+
+func TestRunLintMultipleReports(t *testing.T) {
+	reporters.RegisterReporter("test-count", func(run *reporters.Run) reporters.Reporter { return &countingReporter{} })
+
+	dir := t.TempDir()
+	openrpcFile := writeTempFile(t, "test-openrpc-*.json", `{
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "methods": [{"name": "foo"}]
+}`)
+
+	rules := `rules:
+  method-description:
+    given: "$.methods[*]"
+    then:
+      field: "description"
+      function: "truthy"
+`
+	configuredRulesFile := writeTempFile(t, "test-rules-*.yml", rules+`reporters:
+  - format: test-count
+  - format: junit
+    output: `+filepath.Join(dir, "configured.xml")+`
+`)
+
+	t.Run("flags", func(t *testing.T) {
+		var output bytes.Buffer
+		opts := LintOptions{
+			OpenRPCFile: openrpcFile,
+			RulesFile:   configuredRulesFile,
+			Output:      &output,
+			Reports: []types.ReporterConfig{
+				{Format: "test-count"},
+				{Format: "sarif", Output: filepath.Join(dir, "report.sarif")},
+				{Format: "json", Output: filepath.Join(dir, "report.json")},
+			},
+		}
+		if err := RunLint(opts); ExitCode(err) != ExitLintErrors {
+			t.Fatalf("Expected lint errors exit code, got: %v", err)
+		}
+
+		if output.String() != "1 result(s) from 1 rule(s)\n" {
+			t.Errorf("Expected only the test-count report on the output, got: %s", output.String())
+		}
+		sarif, err := os.ReadFile(filepath.Join(dir, "report.sarif"))
+		if err != nil || !strings.Contains(string(sarif), `"version": "2.1.0"`) {
+			t.Errorf("Expected a SARIF report file, got: %s (%v)", sarif, err)
+		}
+		var report reporters.JSONReport
+		data, err := os.ReadFile(filepath.Join(dir, "report.json"))
+		if err != nil || json.Unmarshal(data, &report) != nil || len(report.Files) != 1 || len(report.Files[0].Results) != 1 {
+			t.Errorf("Expected a JSON report file with 1 result, got: %s (%v)", data, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "configured.xml")); err == nil {
+			t.Errorf("Expected flags to override the configured reporters")
+		}
+	})
+
+	t.Run("config", func(t *testing.T) {
+		var output bytes.Buffer
+		opts := LintOptions{
+			OpenRPCFile: openrpcFile,
+			RulesFile:   configuredRulesFile,
+			Output:      &output,
+		}
+		if err := RunLint(opts); ExitCode(err) != ExitLintErrors {
+			t.Fatalf("Expected lint errors exit code, got: %v", err)
+		}
+
+		if output.String() != "1 result(s) from 1 rule(s)\n" {
+			t.Errorf("Expected the configured test-count report on the output, got: %s", output.String())
+		}
+		junit, err := os.ReadFile(filepath.Join(dir, "configured.xml"))
+		if err != nil || !strings.Contains(string(junit), "<testsuites") {
+			t.Errorf("Expected the configured JUnit report file, got: %s (%v)", junit, err)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		var output bytes.Buffer
+		opts := LintOptions{
+			OpenRPCFile: openrpcFile,
+			RulesFile:   writeTempFile(t, "test-rules-*.yml", rules),
+			Output:      &output,
+			Reports:     []types.ReporterConfig{{Format: "nope"}},
+		}
+		err := RunLint(opts)
+		if ExitCode(err) != ExitMisconfigured {
+			t.Fatalf("Expected misconfigured exit code for an unknown format, got: %v", err)
+		}
+		if !strings.Contains(output.String(), `unknown report format "nope"`) {
+			t.Errorf("Expected unknown format error, got: %s", output.String())
+		}
+	})
+
+	t.Run("shared output", func(t *testing.T) {
+		for _, reports := range [][]types.ReporterConfig{
+			{{Format: "json"}, {Format: "sarif"}},
+			{{Format: "json", Output: filepath.Join(dir, "report")}, {Format: "sarif", Output: filepath.Join(dir, "report")}},
+			{{Format: "json", Output: filepath.Join(dir, "report")}, {Format: "sarif", Output: dir + "/./report"}},
+		} {
+			var output bytes.Buffer
+			opts := LintOptions{
+				OpenRPCFile: openrpcFile,
+				RulesFile:   writeTempFile(t, "test-rules-*.yml", rules),
+				Output:      &output,
+				Reports:     reports,
+			}
+			err := RunLint(opts)
+			if ExitCode(err) != ExitMisconfigured {
+				t.Fatalf("Expected misconfigured exit code for reports sharing an output, got: %v", err)
+			}
+			if !strings.Contains(output.String(), "json and sarif reports both write to") {
+				t.Errorf("Expected shared output error, got: %s", output.String())
+			}
+		}
+	})
+}
+
+func TestRunLintJSONReport(t *testing.T) {
+	openrpcFile := writeTempFile(t, "test-openrpc-*.json", `{
+  "info": {"title": "Test API", "version": "1.0.0", "x-lint-ignore": {"info-description": "Not yet"}},
+  "methods": [{"name": "foo"}, {"name": "bar"}]
+}`)
+
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  method-description:
+    description: "Methods must have descriptions"
+    documentation: "https://example.com/rules/method-description"
+    given: "$.methods[*]"
+    then:
+      field: "description"
+      function: "truthy"
+  method-summary:
+    given: "$.methods[*]"
+    severity: warn
+    then:
+      field: "summary"
+      function: "truthy"
+  info-description:
+    given: "$.info"
+    then:
+      field: "description"
+      function: "truthy"
+  info-title:
+    given: "$.info"
+    then:
+      field: "title"
+      function: "truthy"
+`)
+
+	var output bytes.Buffer
+	opts := LintOptions{
+		OpenRPCFile: openrpcFile,
+		RulesFile:   rulesFile,
+		Output:      &output,
+		Format:      "json",
+	}
+	if err := RunLint(opts); ExitCode(err) != ExitLintErrors {
+		t.Fatalf("Expected lint errors exit code, got: %v", err)
+	}
+
+	var report reporters.JSONReport
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output.String())
+	}
+
+	if report.Version != reporters.JSONReportVersion || report.Tool.Name != "openrpc-linter" || report.Tool.Version != Version {
+		t.Errorf("Unexpected report header: %+v %+v", report.Version, report.Tool)
+	}
+	if report.Ruleset.Source != rulesFile {
+		t.Errorf("Expected ruleset source %s, got %s", rulesFile, report.Ruleset.Source)
+	}
+
+	expectedSummary := reporters.JSONSummary{
+		TotalRules: 4,
+		BySeverity: map[string]int{"error": 2, "warn": 2, "info": 0, "hint": 0},
+		ByRule: map[string]map[string]int{
+			"info-description":   {},
+			"info-title":         {},
+			"method-description": {"error": 2},
+			"method-summary":     {"warn": 2},
+		},
+		Suppressed: 1,
+	}
+	if !reflect.DeepEqual(report.Summary, expectedSummary) {
+		t.Errorf("Expected summary %+v, got %+v", expectedSummary, report.Summary)
+	}
+
+	if len(report.Files) != 1 || report.Files[0].Path != openrpcFile || len(report.Files[0].Results) != 5 {
+		t.Fatalf("Expected 5 results for %s, got: %s", openrpcFile, output.String())
+	}
+	result := report.Files[0].Results[1]
+	expected := reporters.JSONResult{
+		RuleID:           "method-description",
+		RuleDescription:  "Methods must have descriptions",
+		DocumentationURL: "https://example.com/rules/method-description",
+		Severity:         "error",
+		Message:          "Missing required field 'description' at $.methods[0]",
+		JSONPath:         "$.methods[0].description",
+		Path:             []string{"methods", "0", "description"},
+		Range:            &types.Range{Start: types.Position{Line: 3, Column: 15}, End: types.Position{Line: 3, Column: 30}},
+	}
+	expected.Fingerprint = types.Fingerprint(types.RuleFunctionResult{
+		RuleID:  expected.RuleID,
+		File:    openrpcFile,
+		Path:    expected.Path,
+		Message: expected.Message,
+	})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected result %+v at %v, got %+v at %v", expected, *expected.Range, result, *result.Range)
+	}
+}
+
+func TestRunLintFingerprints(t *testing.T) {
+	rulesFile := writeTempFile(t, "test-rules-*.yml", `rules:
+  method-description:
+    given: "$.methods[*]"
+    severity: warn
+    then:
+      field: "description"
+      function: "truthy"
+`)
+
+	// The same document, reformatted so the finding moves to another line
+	dir := t.TempDir()
+	openrpcFile := filepath.Join(dir, "openrpc.json")
+	documents := []string{
+		`{"info": {"title": "Test API", "version": "1.0.0"}, "methods": [{"name": "foo"}]}`,
+		"{\n\n  \"info\": {\"title\": \"Test API\", \"version\": \"1.0.0\"},\n\n  \"methods\": [\n    {\"name\": \"foo\"}\n  ]\n}",
+	}
+
+	lint := func(format string) string {
+		var output bytes.Buffer
+		opts := LintOptions{
+			OpenRPCFile: openrpcFile,
+			RulesFile:   rulesFile,
+			Output:      &output,
+			Format:      format,
+		}
+		if err := RunLint(opts); err != nil {
+			t.Fatalf("RunLint should succeed when only warnings are found, but got: %v", err)
+		}
+		return output.String()
+	}
+
+	var fingerprints []string
+	for _, document := range documents {
+		if err := os.WriteFile(openrpcFile, []byte(document), 0644); err != nil {
+			t.Fatalf("Failed to write document: %v", err)
+		}
+
+		var report reporters.JSONReport
+		if err := json.Unmarshal([]byte(lint("json")), &report); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v", err)
+		}
+		if len(report.Files) != 1 || len(report.Files[0].Results) != 1 {
+			t.Fatalf("Expected 1 result, got: %+v", report.Files)
+		}
+		fingerprint := report.Files[0].Results[0].Fingerprint
+		if fingerprint == "" {
+			t.Fatalf("Expected the JSON result to have a fingerprint")
+		}
+		fingerprints = append(fingerprints, fingerprint)
+
+		var sarif struct {
+			Runs []struct {
+				Results []struct {
+					Fingerprints map[string]string `json:"fingerprints"`
+				} `json:"results"`
+			} `json:"runs"`
+		}
+		if err := json.Unmarshal([]byte(lint("sarif")), &sarif); err != nil {
+			t.Fatalf("Failed to parse SARIF output: %v", err)
+		}
+		if got := sarif.Runs[0].Results[0].Fingerprints["openrpc-linter/v1"]; got != fingerprint {
+			t.Errorf("Expected SARIF fingerprint %s, got %s", fingerprint, got)
+		}
+
+		var issues []struct {
+			Fingerprint string `json:"fingerprint"`
+		}
+		if err := json.Unmarshal([]byte(lint("gitlab")), &issues); err != nil {
+			t.Fatalf("Failed to parse GitLab output: %v", err)
+		}
+		if issues[0].Fingerprint != fingerprint {
+			t.Errorf("Expected GitLab fingerprint %s, got %s", fingerprint, issues[0].Fingerprint)
+		}
+	}
+
+	if fingerprints[0] != fingerprints[1] {
+		t.Errorf("Expected the fingerprint to survive reformatting, got %s and %s", fingerprints[0], fingerprints[1])
+	}
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
-	"os"
 	"github.com/spf13/cobra"
+	"os"
 )
 
+// Version is the version of the linter, shown by --version and in reports.
+var Version = "dev"
+
 var rootCmd = &cobra.Command{
 	Use:   "openrpc-linter",
 	Short: "A linter for OpenRPC documents",
@@ -12,7 +15,8 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
+	rootCmd.Version = Version
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
-} 
\ No newline at end of file
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -74,12 +75,13 @@ func TestValidateCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create test file
-			err := os.WriteFile(tt.filename, []byte(tt.fileContent), 0644)
+			// Create the test file outside the package directory, so it is
+			// not left behind if validation exits the process
+			filename := filepath.Join(t.TempDir(), tt.filename)
+			err := os.WriteFile(filename, []byte(tt.fileContent), 0644)
 			if err != nil {
 				t.Fatalf("Failed to create test file: %v", err)
 			}
-			defer os.Remove(tt.filename) // Clean up
 
 			// Capture stdout since the validate command uses fmt.Printf
 			oldStdout := os.Stdout
@@ -87,7 +89,7 @@ func TestValidateCommand(t *testing.T) {
 			os.Stdout = w
 
 			// Execute the validate command function directly
-			validateCmd.Run(validateCmd, []string{tt.filename})
+			validateCmd.Run(validateCmd, []string{filename})
 
 			// Restore stdout and read captured output
 			w.Close()
@@ -0,0 +1,59 @@
+package formats
+
+import (
+	"strings"
+	"sync"
+)
+
+// FormatDetector reports whether a decoded document is of a given format.
+// Detectors may be called concurrently and must not modify the document.
+type FormatDetector func(document interface{}) bool
+
+var (
+	registryMu     sync.RWMutex
+	formatRegistry = make(map[string]FormatDetector)
+)
+
+func init() {
+	RegisterFormats()
+}
+
+func RegisterFormats() {
+	RegisterFormat("openrpc-1.0", openRPCVersion("1.0"))
+	RegisterFormat("openrpc-1.1", openRPCVersion("1.1"))
+	RegisterFormat("openrpc-1.2", openRPCVersion("1.2"))
+	RegisterFormat("openrpc-1.3", openRPCVersion("1.3"))
+	RegisterFormat("openrpc-1.4", openRPCVersion("1.4"))
+}
+
+// RegisterFormat adds or replaces the detector for the given format name.
+// It is safe to call while rules are running.
+func RegisterFormat(name string, detector FormatDetector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	formatRegistry[name] = detector
+}
+
+// GetFormat returns the detector for the given format name, or nil if there
+// is none.
+func GetFormat(name string) FormatDetector {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return formatRegistry[name]
+}
+
+// openRPCVersion detects documents whose `openrpc` field is the given
+// major.minor version or any patch release of it.
+func openRPCVersion(version string) FormatDetector {
+	return func(document interface{}) bool {
+		doc, ok := document.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		openrpc, ok := doc["openrpc"].(string)
+		if !ok {
+			return false
+		}
+		return openrpc == version || strings.HasPrefix(openrpc, version+".")
+	}
+}
@@ -0,0 +1,78 @@
+package functions
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/shanejonas/openrpc-linter/types"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// PatternRule checks that a string value matches the `match` regular
+// expression and/or does not match the `notMatch` one. Non-string values
+// are ignored. An invalid expression is reported as an error result, since
+// it means the rule is misconfigured rather than the document wrong.
+type PatternRule struct{}
+
+// compiledPatterns caches each regular expression compiled by PatternRule,
+// along with the error compiling it, by its source.
+var compiledPatterns sync.Map
+
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if cached, ok := compiledPatterns.Load(expr); ok {
+		return cached.(compiledPattern).re, cached.(compiledPattern).err
+	}
+	re, err := regexp.Compile(expr)
+	compiledPatterns.Store(expr, compiledPattern{re: re, err: err})
+	return re, err
+}
+
+func (r *PatternRule) RunRule(value interface{}, context types.RuleFunctionContext) []types.RuleFunctionResult {
+	var results []types.RuleFunctionResult
+
+	var options map[string]interface{}
+	if context.Action != nil {
+		options = context.Action.FunctionOptions
+	}
+
+	var matchRe, notMatchRe *regexp.Regexp
+	if match, ok := options["match"].(string); ok {
+		re, err := compilePattern(match)
+		if err != nil {
+			return append(results, types.RuleFunctionResult{Message: fmt.Sprintf("Invalid match pattern '%s': %v", match, err), Kind: types.KindError})
+		}
+		matchRe = re
+	}
+	if notMatch, ok := options["notMatch"].(string); ok {
+		re, err := compilePattern(notMatch)
+		if err != nil {
+			return append(results, types.RuleFunctionResult{Message: fmt.Sprintf("Invalid notMatch pattern '%s': %v", notMatch, err), Kind: types.KindError})
+		}
+		notMatchRe = re
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return results
+	}
+
+	if matchRe != nil && !matchRe.MatchString(str) {
+		results = append(results, types.RuleFunctionResult{Message: fmt.Sprintf("'%s' must match the pattern '%s'", str, matchRe)})
+	}
+	if notMatchRe != nil && notMatchRe.MatchString(str) {
+		results = append(results, types.RuleFunctionResult{Message: fmt.Sprintf("'%s' must not match the pattern '%s'", str, notMatchRe)})
+	}
+
+	return results
+}
+
+func (r *PatternRule) GetSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{}
+}
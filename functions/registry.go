@@ -1,13 +1,44 @@
 package functions
 
-import "github.com/shanejonas/openrpc-linter/types"
+import (
+	"sync"
 
-var FunctionRegistry = make(map[string]types.RuleFunction)
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+var (
+	registryMu       sync.RWMutex
+	functionRegistry = make(map[string]types.RuleFunction)
+)
+
+// FunctionRegistry is the map behind RegisterFunction and GetFunction.
+//
+// Deprecated: Use RegisterFunction and GetFunction instead. Rules run
+// concurrently, so writing to the map directly is only safe before any
+// rules run.
+var FunctionRegistry = functionRegistry
 
 func init() {
 	RegisterFunctions()
 }
 
 func RegisterFunctions() {
-	FunctionRegistry["truthy"] = &TruthyRule{}
+	RegisterFunction("truthy", &TruthyRule{})
+	RegisterFunction("pattern", &PatternRule{})
+}
+
+// RegisterFunction adds or replaces the rule function with the given name.
+// It is safe to call while rules are running.
+func RegisterFunction(name string, ruleFunc types.RuleFunction) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	functionRegistry[name] = ruleFunc
+}
+
+// GetFunction returns the rule function with the given name, or nil if
+// there is none.
+func GetFunction(name string) types.RuleFunction {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return functionRegistry[name]
 }
@@ -9,6 +9,8 @@ import (
 	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
+// TruthyRule checks that a value is present and is not an empty string or
+// "null".
 type TruthyRule struct{}
 
 func (r *TruthyRule) RunRule(value interface{}, context types.RuleFunctionContext) []types.RuleFunctionResult {
@@ -26,11 +28,13 @@ func (r *TruthyRule) RunRule(value interface{}, context types.RuleFunctionContex
 
 	if !isTruthy {
 		var message string
-		if context.Rule != nil && context.Rule.Then != nil && context.Rule.Then.Field != "" {
-			fieldName := context.Rule.Then.Field
-			jsonPath := context.Rule.Given
+		if context.Rule != nil && context.Action != nil && context.Action.Field != "" {
+			fieldName := context.Action.Field
+			jsonPath := context.Given
 
-			if context.ArrayIndex != nil {
+			if context.Path != nil {
+				jsonPath = types.JSONPath(context.Path)
+			} else if context.ArrayIndex != nil {
 				jsonPath = strings.Replace(jsonPath, "[*]", fmt.Sprintf("[%d]", *context.ArrayIndex), 1)
 			}
 
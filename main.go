@@ -2,6 +2,10 @@ package main
 
 import "github.com/shanejonas/openrpc-linter/cmd"
 
+// version is set at build time, e.g. by GoReleaser's default ldflags.
+var version = "dev"
+
 func main() {
+	cmd.Version = version
 	cmd.Execute()
 }
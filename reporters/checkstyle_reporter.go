@@ -0,0 +1,96 @@
+package reporters
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// CheckstyleReporter writes results as Checkstyle XML, grouped by file. The
+// linted file is listed even when it has no results. Suppressed and
+// baselined results are left out.
+type CheckstyleReporter struct {
+	Run *Run
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (r *CheckstyleReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	report := checkstyleReport{Version: "4.3"}
+	fileIndex := make(map[string]int)
+	addFile := func(file string) int {
+		i, ok := fileIndex[file]
+		if !ok {
+			i = len(report.Files)
+			fileIndex[file] = i
+			report.Files = append(report.Files, checkstyleFile{Name: file})
+		}
+		return i
+	}
+
+	if r.Run != nil && r.Run.File != "" {
+		addFile(r.Run.File)
+	}
+	for _, result := range results {
+		if result.Suppressed || result.Baselined {
+			continue
+		}
+
+		i := addFile(result.File)
+
+		checkstyleErr := checkstyleError{
+			Severity: checkstyleSeverity(result),
+			Message:  result.Message,
+			Source:   result.RuleID,
+		}
+		if result.Range != nil {
+			checkstyleErr.Line = result.Range.Start.Line
+			checkstyleErr.Column = result.Range.Start.Column
+		}
+		report.Files[i].Errors = append(report.Files[i].Errors, checkstyleErr)
+	}
+
+	if _, err := io.WriteString(output, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(output)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(output, "\n")
+	return err
+}
+
+// checkstyleSeverity maps a result to a Checkstyle severity.
+func checkstyleSeverity(result types.RuleFunctionResult) string {
+	if result.IsError() {
+		return "error"
+	}
+	switch result.Severity {
+	case types.SeverityWarn:
+		return "warning"
+	case types.SeverityInfo, types.SeverityHint:
+		return "info"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,52 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestCheckstyleReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &CheckstyleReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse Checkstyle output: %v\n%s", err, output.String())
+	}
+	if report.Version != "4.3" || len(report.Files) != 1 || report.Files[0].Name != "api/openrpc.json" {
+		t.Fatalf("Expected a single file api/openrpc.json, got: %s", output.String())
+	}
+
+	// Suppressed and baselined results are left out, the message survives
+	// XML escaping, and the rule error has no line.
+	expected := []checkstyleError{
+		{Line: 4, Column: 5, Severity: "warning", Message: testResults()[0].Message, Source: "method-docs"},
+		{Line: 4, Column: 41, Severity: "error", Message: testResults()[1].Message, Source: "param-name"},
+		{Severity: "error", Message: "unknown function: nope", Source: "broken"},
+	}
+	if !reflect.DeepEqual(report.Files[0].Errors, expected) {
+		t.Errorf("Expected errors:\n%+v\nGot:\n%+v", expected, report.Files[0].Errors)
+	}
+}
+
+func TestCheckstyleReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &CheckstyleReporter{Run: testRun(t)}
+	if err := reporter.Format(nil, 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// The linted file is listed even when it has no results.
+	expected := xml.Header + `<checkstyle version="4.3">
+  <file name="api/openrpc.json"></file>
+</checkstyle>
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
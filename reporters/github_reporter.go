@@ -0,0 +1,73 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// GitHubReporter writes results as GitHub Actions workflow commands, so that
+// they show up as annotations on the lines they were found on. Suppressed
+// and baselined results are left out.
+type GitHubReporter struct{}
+
+func (r *GitHubReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	for _, result := range results {
+		if result.Suppressed || result.Baselined {
+			continue
+		}
+
+		var properties []string
+		if result.File != "" {
+			properties = append(properties, "file="+escapeGitHubProperty(filepath.ToSlash(result.File)))
+			if result.Range != nil {
+				properties = append(properties,
+					fmt.Sprintf("line=%d", result.Range.Start.Line),
+					fmt.Sprintf("col=%d", result.Range.Start.Column),
+					fmt.Sprintf("endLine=%d", result.Range.End.Line),
+					fmt.Sprintf("endColumn=%d", result.Range.End.Column),
+				)
+			}
+		}
+		title := result.RuleID
+		if result.IsError() {
+			title += " (rule execution error)"
+		}
+		properties = append(properties, "title="+escapeGitHubProperty(title))
+
+		if _, err := fmt.Fprintf(output, "::%s %s::%s\n", githubCommand(result), strings.Join(properties, ","), escapeGitHubData(result.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubCommand returns the workflow command for a result's severity.
+func githubCommand(result types.RuleFunctionResult) string {
+	if result.IsError() {
+		return "error"
+	}
+	switch result.Severity {
+	case types.SeverityWarn:
+		return "warning"
+	case types.SeverityInfo, types.SeverityHint:
+		return "notice"
+	default:
+		return "error"
+	}
+}
+
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
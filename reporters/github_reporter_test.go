@@ -0,0 +1,57 @@
+package reporters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &GitHubReporter{}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Suppressed and baselined results are left out.
+	expected := "::warning file=api/openrpc.json,line=4,col=5,endLine=4,endColumn=49,title=method-docs::Missing required field 'description' at $.methods[0]\n" +
+		"::error file=api/openrpc.json,line=4,col=41,endLine=4,endColumn=46,title=param-name::\"a&b\" must match <^\\w+$> | 100%25, really: yes\n" +
+		"::error file=api/openrpc.json,title=broken (rule execution error)::unknown function: nope\n"
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestGitHubReporterEscaping(t *testing.T) {
+	results := []types.RuleFunctionResult{{
+		RuleID:   "odd:rule,id",
+		Severity: types.SeverityInfo,
+		Message:  "100% wrong\r\nsee: docs, please",
+		File:     "dir:a,b/openrpc.json",
+	}}
+
+	var output bytes.Buffer
+	reporter := &GitHubReporter{}
+	if err := reporter.Format(results, 1, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Properties also escape the ':' and ',' that delimit them; the message
+	// only escapes '%' and line breaks.
+	expected := "::notice file=dir%3Aa%2Cb/openrpc.json,title=odd%3Arule%2Cid::100%25 wrong%0D%0Asee: docs, please\n"
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestGitHubReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &GitHubReporter{}
+	if err := reporter.Format(nil, 3, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("Expected no output, got: %s", output.String())
+	}
+}
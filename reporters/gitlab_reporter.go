@@ -0,0 +1,86 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// GitLabReporter writes results as a GitLab Code Quality report, so that
+// they show up on merge request diffs. Suppressed and baselined results are
+// left out.
+type GitLabReporter struct{}
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+func (r *GitLabReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	issues := []gitlabIssue{}
+	for _, result := range results {
+		if result.Suppressed || result.Baselined {
+			continue
+		}
+
+		// Code Quality requires a line, so results without a location,
+		// such as rule execution errors, are placed on the first line.
+		lines := gitlabLines{Begin: 1}
+		if result.Range != nil {
+			lines = gitlabLines{Begin: result.Range.Start.Line, End: result.Range.End.Line}
+		}
+		fingerprint := result.Fingerprint
+		if fingerprint == "" {
+			fingerprint = types.Fingerprint(result)
+		}
+		path := ""
+		if result.File != "" {
+			path = types.FingerprintFile(result.File)
+		}
+
+		issues = append(issues, gitlabIssue{
+			Description: result.Message,
+			CheckName:   result.RuleID,
+			Fingerprint: fingerprint,
+			Severity:    gitlabSeverity(result),
+			Location: gitlabLocation{
+				Path:  path,
+				Lines: lines,
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+// gitlabSeverity maps a result to a Code Quality severity. Rule execution
+// errors are blockers, since the rest of the report may be incomplete.
+func gitlabSeverity(result types.RuleFunctionResult) string {
+	if result.IsError() {
+		return "blocker"
+	}
+	switch result.Severity {
+	case types.SeverityWarn:
+		return "minor"
+	case types.SeverityInfo, types.SeverityHint:
+		return "info"
+	default:
+		return "major"
+	}
+}
@@ -0,0 +1,99 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestGitLabReporter(t *testing.T) {
+	results := testResults()
+
+	var output bytes.Buffer
+	reporter := &GitLabReporter{}
+	if err := reporter.Format(results, 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var issues []gitlabIssue
+	if err := json.Unmarshal(output.Bytes(), &issues); err != nil {
+		t.Fatalf("Failed to parse GitLab output: %v\n%s", err, output.String())
+	}
+
+	// Suppressed and baselined results are left out.
+	expected := []gitlabIssue{
+		{
+			Description: results[0].Message,
+			CheckName:   "method-docs",
+			Fingerprint: "fp-method-docs",
+			Severity:    "minor",
+			Location:    gitlabLocation{Path: "api/openrpc.json", Lines: gitlabLines{Begin: 4, End: 4}},
+		},
+		{
+			Description: results[1].Message,
+			CheckName:   "param-name",
+			Fingerprint: "fp-param-name",
+			Severity:    "major",
+			Location:    gitlabLocation{Path: "api/openrpc.json", Lines: gitlabLines{Begin: 4, End: 4}},
+		},
+		{
+			Description: "unknown function: nope",
+			CheckName:   "broken",
+			Fingerprint: types.Fingerprint(results[4]),
+			Severity:    "blocker",
+			Location:    gitlabLocation{Path: "api/openrpc.json", Lines: gitlabLines{Begin: 1}},
+		},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got: %s", len(expected), output.String())
+	}
+	for i := range expected {
+		if issues[i] != expected[i] {
+			t.Errorf("Issue %d: expected %+v, got %+v", i, expected[i], issues[i])
+		}
+	}
+}
+
+func TestGitLabReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &GitLabReporter{}
+	if err := reporter.Format(nil, 3, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Code Quality expects an array even when there are no issues.
+	if strings.TrimSpace(output.String()) != "[]" {
+		t.Errorf("Expected an empty array, got: %s", output.String())
+	}
+}
+
+func TestGitLabReporterAbsolutePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	results := testResults()[:1]
+	results[0].File = filepath.Join(wd, "api", "openrpc.json")
+
+	var output bytes.Buffer
+	reporter := &GitLabReporter{}
+	if err := reporter.Format(results, 1, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var issues []gitlabIssue
+	if err := json.Unmarshal(output.Bytes(), &issues); err != nil {
+		t.Fatalf("Failed to parse GitLab output: %v\n%s", err, output.String())
+	}
+
+	// Merge request views map paths to files in the repository, so an
+	// absolute path is made relative to the working directory.
+	if len(issues) != 1 || issues[0].Location.Path != "api/openrpc.json" {
+		t.Errorf("Expected path api/openrpc.json, got: %s", output.String())
+	}
+}
@@ -0,0 +1,92 @@
+package reporters
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// HTMLReporter writes a single, self-contained HTML page with the same
+// content as MarkdownReporter. Styles are inlined and nothing is loaded
+// from elsewhere, so the file can be published as a build artifact as is.
+type HTMLReporter struct {
+	Run *Run
+}
+
+var htmlTemplate = template.Must(template.New("html").Funcs(template.FuncMap{
+	"icon": severityIcon,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>OpenRPC lint report{{if .File}} – {{.File}}{{end}}</title>
+<style>
+  body { font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 2em auto; max-width: 72em; padding: 0 1em; }
+  h1, h2, h3 { line-height: 1.25; }
+  h2 { border-bottom: 1px solid #d1d9e0; padding-bottom: .3em; margin-top: 2em; }
+  code { font: 12px ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; background: #f6f8fa; border-radius: 4px; padding: .1em .3em; }
+  table { border-collapse: collapse; width: 100%; margin: 1em 0; }
+  th, td { border: 1px solid #d1d9e0; padding: 6px 10px; text-align: left; vertical-align: top; }
+  th { background: #f6f8fa; }
+  td.count { text-align: right; font-variant-numeric: tabular-nums; }
+  .meta { color: #59636e; }
+  .summary { font-size: 1.2em; font-weight: 600; }
+  .error { color: #d1242f; }
+  .warn { color: #9a6700; }
+  .info, .hint { color: #0969da; }
+  .passed { color: #1a7f37; }
+</style>
+</head>
+<body>
+<h1>OpenRPC lint report</h1>
+<p class="meta">{{if .File}}<code>{{.File}}</code> · {{end}}{{.TotalRules}} rules{{if .ToolVersion}} · openrpc-linter {{.ToolVersion}}{{end}}</p>
+
+{{if .Counts.Total -}}
+<p class="summary {{if .Counts.Errors}}error{{else}}warn{{end}}">{{.Counts.Total}} problem(s): {{.Counts.Errors}} error(s), {{.Counts.Warnings}} warning(s), {{.Counts.Infos}} info{{if .Skipped}} <span class="meta">({{.Skipped}} suppressed or baselined)</span>{{end}}</p>
+{{- else if not .ExecutionErrors -}}
+<p class="summary passed">✅ No problems found{{if .Skipped}} <span class="meta">({{.Skipped}} suppressed or baselined)</span>{{end}}</p>
+{{- end}}
+{{if .ExecutionErrors -}}
+<p class="summary error">⛔ {{len .ExecutionErrors}} rule(s) failed to run; check the rules configuration</p>
+{{- end}}
+
+<h2>Rules</h2>
+<table>
+<thead><tr><th>Rule</th><th>Severity</th><th>Errors</th><th>Warnings</th><th>Info</th><th>Description</th></tr></thead>
+<tbody>
+{{range .Rules -}}
+<tr><td>{{if .Failed}}⛔{{else if .Counts.Total}}{{icon .Severity}}{{else}}✅{{end}} {{template "rule" .}}</td><td class="{{.Severity}}">{{.Severity}}</td><td class="count">{{.Counts.Errors}}</td><td class="count">{{.Counts.Warnings}}</td><td class="count">{{.Counts.Infos}}</td><td>{{.Description}}</td></tr>
+{{end -}}
+</tbody>
+</table>
+{{if .ExecutionErrors}}
+<h2>Rule execution errors</h2>
+<ul>
+{{range .ExecutionErrors -}}
+<li><code>{{.RuleID}}</code>: {{.Message}}</li>
+{{end -}}
+</ul>
+{{end}}
+{{- if .Methods}}
+<h2>Results by method</h2>
+{{range .Methods}}
+<h3>{{if .Name}}<code>{{.Name}}</code>{{else}}Outside methods{{end}}</h3>
+<table>
+<thead><tr><th>Severity</th><th>Rule</th><th>Message</th><th>Location</th></tr></thead>
+<tbody>
+{{range .Results -}}
+<tr><td class="{{.Severity}}">{{icon .Severity}} {{.Severity}}</td><td>{{template "rule" .}}</td><td>{{.Message}}</td><td>{{if .Location}}<code>{{.Location}}</code>{{if .JSONPath}} {{end}}{{end}}{{if .JSONPath}}<code>{{.JSONPath}}</code>{{end}}</td></tr>
+{{end -}}
+</tbody>
+</table>
+{{end}}
+{{- end}}
+</body>
+</html>
+{{define "rule"}}{{if .Documentation}}<a href="{{.Documentation}}"><code>{{.RuleID}}</code></a>{{else}}<code>{{.RuleID}}</code>{{end}}{{end}}`))
+
+func (r *HTMLReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	return htmlTemplate.Execute(output, summarize(results, totalRules, r.Run))
+}
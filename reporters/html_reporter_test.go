@@ -0,0 +1,61 @@
+package reporters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &HTMLReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	html := output.String()
+	for _, expected := range []string{
+		"<!DOCTYPE html>",
+		"<title>OpenRPC lint report – api/openrpc.json</title>",
+		`<p class="summary error">2 problem(s): 1 error(s), 1 warning(s), 0 info <span class="meta">(2 suppressed or baselined)</span></p>`,
+		`<p class="summary error">⛔ 1 rule(s) failed to run; check the rules configuration</p>`,
+		`<a href="https://example.com/rules/method-docs"><code>method-docs</code></a>`,
+		"<td>Methods must have &lt;descriptions&gt;</td>",
+		"<li><code>broken</code>: unknown function: nope</li>",
+		"<h3><code>foo</code></h3>",
+		"<td>&#34;a&amp;b&#34; must match &lt;^\\w&#43;$&gt; | 100%, really: yes</td>",
+		"<code>$.methods[0].params[0].name</code>",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected %q in the output, got:\n%s", expected, html)
+		}
+	}
+
+	// Suppressed and baselined results are only counted.
+	for _, skipped := range []string{"Missing required field &#39;summary&#39;", "Old finding"} {
+		if strings.Contains(html, skipped) {
+			t.Errorf("Expected %q to be left out, got:\n%s", skipped, html)
+		}
+	}
+	for _, external := range []string{"<link", "<script", "src="} {
+		if strings.Contains(html, external) {
+			t.Errorf("Expected a self-contained HTML report, found %q", external)
+		}
+	}
+}
+
+func TestHTMLReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &HTMLReporter{}
+	if err := reporter.Format(nil, 3, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	html := output.String()
+	if !strings.Contains(html, `<p class="summary passed">✅ No problems found</p>`) || !strings.HasSuffix(html, "</html>\n") {
+		t.Errorf("Expected a complete report with no problems, got:\n%s", html)
+	}
+	if strings.Contains(html, "Results by method") || strings.Contains(html, "Rule execution errors") {
+		t.Errorf("Expected no result sections, got:\n%s", html)
+	}
+}
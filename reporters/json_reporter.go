@@ -7,10 +7,157 @@ import (
 	"github.com/shanejonas/openrpc-linter/types"
 )
 
-type JSONReporter struct{}
+// JSONReportVersion is the version of the JSON report format. It changes
+// only when fields are removed or change meaning.
+const JSONReportVersion = 1
+
+type JSONReporter struct {
+	Run *Run
+}
+
+// JSONReport is the document written by JSONReporter.
+type JSONReport struct {
+	Version    int              `json:"version"`
+	Tool       JSONTool         `json:"tool"`
+	Ruleset    JSONRuleset      `json:"ruleset"`
+	Summary    JSONSummary      `json:"summary"`
+	Files      []JSONFileReport `json:"files"`
+	RuleErrors []JSONResult     `json:"ruleErrors"` // Rules that could not run
+}
+
+type JSONTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type JSONRuleset struct {
+	Source string `json:"source,omitempty"` // Path of the rules file
+}
+
+// JSONSummary counts the results that were reported, i.e. not suppressed or
+// baselined, by severity and by rule. Every rule run appears in ByRule, with
+// zero counts if it passed.
+type JSONSummary struct {
+	TotalRules int                       `json:"totalRules"`
+	BySeverity map[string]int            `json:"bySeverity"`
+	ByRule     map[string]map[string]int `json:"byRule"`
+	Suppressed int                       `json:"suppressed"`
+	Baselined  int                       `json:"baselined"`
+	RuleErrors int                       `json:"ruleErrors"`
+}
+
+type JSONFileReport struct {
+	Path    string       `json:"path"`
+	Results []JSONResult `json:"results"`
+}
+
+type JSONResult struct {
+	RuleID            string       `json:"ruleId"`
+	Fingerprint       string       `json:"fingerprint,omitempty"`
+	RuleDescription   string       `json:"ruleDescription,omitempty"`
+	DocumentationURL  string       `json:"documentationUrl,omitempty"`
+	Severity          string       `json:"severity"`
+	Message           string       `json:"message"`
+	JSONPath          string       `json:"jsonPath,omitempty"`
+	Path              []string     `json:"path,omitempty"`
+	Range             *types.Range `json:"range,omitempty"`
+	Related           []string     `json:"related,omitempty"` // JSONPaths of other usages of a shared definition
+	Suppressed        bool         `json:"suppressed,omitempty"`
+	SuppressionReason string       `json:"suppressionReason,omitempty"`
+	IgnoredAt         []string     `json:"ignoredAt,omitempty"` // JSONPaths of usages that ignore a finding reported elsewhere
+	Baselined         bool         `json:"baselined,omitempty"`
+}
 
 func (r *JSONReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	report := JSONReport{
+		Version: JSONReportVersion,
+		Tool:    JSONTool{Name: toolName},
+		Summary: JSONSummary{
+			TotalRules: totalRules,
+			BySeverity: make(map[string]int),
+			ByRule:     make(map[string]map[string]int),
+		},
+		Files:      []JSONFileReport{},
+		RuleErrors: []JSONResult{},
+	}
+	if r.Run != nil {
+		report.Tool.Version = r.Run.ToolVersion
+		report.Ruleset.Source = r.Run.RulesFile
+	}
+
+	for _, severity := range []string{types.SeverityError, types.SeverityWarn, types.SeverityInfo, types.SeverityHint} {
+		report.Summary.BySeverity[severity] = 0
+	}
+	for _, id := range r.Run.ruleIDs(results) {
+		report.Summary.ByRule[id] = make(map[string]int)
+	}
+
+	fileIndex := make(map[string]int)
+	addFile := func(file string) int {
+		i, ok := fileIndex[file]
+		if !ok {
+			i = len(report.Files)
+			fileIndex[file] = i
+			report.Files = append(report.Files, JSONFileReport{Path: file, Results: []JSONResult{}})
+		}
+		return i
+	}
+	if r.Run != nil && r.Run.File != "" {
+		addFile(r.Run.File)
+	}
+
+	for _, result := range results {
+		entry := r.jsonResult(result)
+		if result.IsError() {
+			report.RuleErrors = append(report.RuleErrors, entry)
+			report.Summary.RuleErrors++
+			continue
+		}
+
+		switch {
+		case result.Suppressed:
+			report.Summary.Suppressed++
+		case result.Baselined:
+			report.Summary.Baselined++
+		default:
+			report.Summary.BySeverity[result.Severity]++
+			if byRule, ok := report.Summary.ByRule[result.RuleID]; ok {
+				byRule[result.Severity]++
+			}
+		}
+
+		i := addFile(result.File)
+		report.Files[i].Results = append(report.Files[i].Results, entry)
+	}
+
 	encoder := json.NewEncoder(output)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(results)
+	return encoder.Encode(report)
+}
+
+func (r *JSONReporter) jsonResult(result types.RuleFunctionResult) JSONResult {
+	rule := r.Run.rule(result.RuleID)
+	entry := JSONResult{
+		RuleID:            result.RuleID,
+		Fingerprint:       result.Fingerprint,
+		RuleDescription:   rule.Description,
+		DocumentationURL:  rule.Documentation,
+		Severity:          result.Severity,
+		Message:           result.Message,
+		Path:              result.Path,
+		Range:             result.Range,
+		Suppressed:        result.Suppressed,
+		SuppressionReason: result.SuppressionReason,
+		Baselined:         result.Baselined,
+	}
+	if len(result.Path) > 0 {
+		entry.JSONPath = types.JSONPath(result.Path)
+	}
+	for _, related := range result.Related {
+		entry.Related = append(entry.Related, types.JSONPath(related))
+	}
+	for _, ignored := range result.IgnoredAt {
+		entry.IgnoredAt = append(entry.IgnoredAt, types.JSONPath(ignored))
+	}
+	return entry
 }
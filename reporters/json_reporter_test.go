@@ -0,0 +1,103 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestJSONReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &JSONReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output.String())
+	}
+	if report.Version != JSONReportVersion || report.Tool.Version != "1.2.3" || report.Ruleset.Source != "rules.yml" {
+		t.Errorf("Unexpected report header: %+v %+v %+v", report.Version, report.Tool, report.Ruleset)
+	}
+
+	// Only reported results are counted, and rules that passed appear with
+	// zero counts.
+	summary := report.Summary
+	if summary.TotalRules != 4 || summary.Suppressed != 1 || summary.Baselined != 1 || summary.RuleErrors != 1 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if want := map[string]int{types.SeverityError: 1, types.SeverityWarn: 1, types.SeverityInfo: 0, types.SeverityHint: 0}; !reflect.DeepEqual(summary.BySeverity, want) {
+		t.Errorf("Expected severities %v, got %v", want, summary.BySeverity)
+	}
+	wantByRule := map[string]map[string]int{
+		"broken":      {},
+		"info-title":  {},
+		"method-docs": {types.SeverityWarn: 1},
+		"param-name":  {types.SeverityError: 1},
+	}
+	if !reflect.DeepEqual(summary.ByRule, wantByRule) {
+		t.Errorf("Expected rule counts %v, got %v", wantByRule, summary.ByRule)
+	}
+
+	if len(report.Files) != 1 || report.Files[0].Path != "api/openrpc.json" || len(report.Files[0].Results) != 4 {
+		t.Fatalf("Expected the four findings under api/openrpc.json, got: %+v", report.Files)
+	}
+	warning := report.Files[0].Results[0]
+	if warning.RuleID != "method-docs" || warning.Fingerprint != "fp-method-docs" || warning.JSONPath != "$.methods[0].description" ||
+		warning.RuleDescription != "Methods must have <descriptions>" || warning.DocumentationURL != "https://example.com/rules/method-docs" {
+		t.Errorf("Unexpected result: %+v", warning)
+	}
+	if suppressed := report.Files[0].Results[2]; !suppressed.Suppressed || suppressed.SuppressionReason != "Legacy method" {
+		t.Errorf("Expected a suppressed result, got %+v", suppressed)
+	}
+	if baselined := report.Files[0].Results[3]; !baselined.Baselined {
+		t.Errorf("Expected a baselined result, got %+v", baselined)
+	}
+
+	if len(report.RuleErrors) != 1 || report.RuleErrors[0].RuleID != "broken" || report.RuleErrors[0].Message != "unknown function: nope" {
+		t.Errorf("Expected the broken rule as a rule error, got %+v", report.RuleErrors)
+	}
+}
+
+func TestJSONReporterWithoutRun(t *testing.T) {
+	results := testResults()
+	results = append(results, types.RuleFunctionResult{
+		Severity: types.SeverityWarn,
+		Kind:     types.KindViolation,
+		Message:  "Finding without a rule",
+		File:     "api/openrpc.json",
+	})
+
+	var output bytes.Buffer
+	reporter := &JSONReporter{}
+	if err := reporter.Format(results, 0, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var report JSONReport
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output.String())
+	}
+
+	// Rules come from the results alone, and a result without a rule ID is
+	// counted by severity only.
+	if report.Tool.Version != "" || report.Ruleset.Source != "" {
+		t.Errorf("Expected no tool version or ruleset without a run, got %+v %+v", report.Tool, report.Ruleset)
+	}
+	if _, ok := report.Summary.ByRule[""]; ok || len(report.Summary.ByRule) != 3 {
+		t.Errorf("Expected rule counts for the three named rules, got %v", report.Summary.ByRule)
+	}
+	if report.Summary.BySeverity[types.SeverityWarn] != 2 {
+		t.Errorf("Expected 2 warnings, got %d", report.Summary.BySeverity[types.SeverityWarn])
+	}
+	if len(report.Files) != 1 || len(report.Files[0].Results) != 5 {
+		t.Fatalf("Expected five results under api/openrpc.json, got: %+v", report.Files)
+	}
+	if result := report.Files[0].Results[4]; result.RuleID != "" || result.RuleDescription != "" {
+		t.Errorf("Expected the finding without a rule, got %+v", result)
+	}
+}
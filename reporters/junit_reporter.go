@@ -0,0 +1,185 @@
+package reporters
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// JUnitReporter writes results as JUnit XML, with one testsuite per file and
+// one testcase per rule. A rule with violations fails, a rule that could not
+// run errors, and every other rule passes. Suppressed and baselined results
+// do not fail their rule.
+type JUnitReporter struct {
+	Run *Run
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *JUnitReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	var files []string
+	fileResults := make(map[string]map[string][]types.RuleFunctionResult)
+	addFile := func(file string) {
+		if _, ok := fileResults[file]; !ok {
+			files = append(files, file)
+			fileResults[file] = make(map[string][]types.RuleFunctionResult)
+		}
+	}
+
+	if r.Run != nil {
+		addFile(r.Run.File)
+	}
+	for _, result := range results {
+		if result.Suppressed || result.Baselined {
+			continue
+		}
+		addFile(result.File)
+		fileResults[result.File][result.RuleID] = append(fileResults[result.File][result.RuleID], result)
+	}
+	if len(files) == 0 {
+		addFile("")
+	}
+
+	suites := junitTestSuites{Name: toolName}
+	for _, file := range files {
+		suiteName := file
+		if suiteName == "" {
+			suiteName = toolName
+		}
+
+		suite := junitTestSuite{Name: suiteName}
+		for _, ruleId := range r.Run.ruleIDs(results) {
+			testCase := junitTestCase{Name: ruleId, ClassName: suiteName}
+			ruleResults := fileResults[file][ruleId]
+
+			var violations []types.RuleFunctionResult
+			for _, result := range ruleResults {
+				if result.IsError() {
+					testCase.Error = &junitProblem{Message: result.Message, Type: "rule execution error", Text: result.Message}
+				} else {
+					violations = append(violations, result)
+				}
+			}
+			if testCase.Error != nil {
+				suite.Errors++
+			} else if len(violations) > 0 {
+				testCase.Failure = junitFailure(violations)
+				suite.Failures++
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		// Rules without a known ID still count towards the total so that
+		// the passes add up to totalRules.
+		suite.Tests = len(suite.TestCases)
+		if totalRules > suite.Tests {
+			suite.Tests = totalRules
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(output, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(output)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(output, "\n")
+	return err
+}
+
+// junitFailure summarises a rule's violations in a single failure, typed by
+// the most severe of them.
+func junitFailure(violations []types.RuleFunctionResult) *junitProblem {
+	var lines []string
+	severity := types.SeverityHint
+	for _, result := range violations {
+		if severityRank(result.Severity) > severityRank(severity) {
+			severity = result.Severity
+		}
+		if loc := location(result); loc != "" {
+			lines = append(lines, loc+": "+result.Message)
+		} else {
+			lines = append(lines, result.Message)
+		}
+	}
+	return &junitProblem{
+		Message: fmt.Sprintf("%d violation(s)", len(violations)),
+		Type:    severity,
+		Text:    strings.Join(lines, "\n"),
+	}
+}
+
+// location formats where a result is as file:line:column, followed by its
+// JSONPath when it has one.
+func location(result types.RuleFunctionResult) string {
+	loc := sourceLocation(result)
+	if len(result.Path) > 0 {
+		if loc != "" {
+			loc += " "
+		}
+		loc += types.JSONPath(result.Path)
+	}
+	return loc
+}
+
+// sourceLocation formats where a result is in its file as
+// file:line:column, or just file when its range is unknown.
+func sourceLocation(result types.RuleFunctionResult) string {
+	if result.Range == nil {
+		return result.File
+	}
+	return fmt.Sprintf("%s:%d:%d", result.File, result.Range.Start.Line, result.Range.Start.Column)
+}
+
+// severityRank orders severities from least (hint) to most (error) severe.
+func severityRank(severity string) int {
+	switch severity {
+	case types.SeverityHint:
+		return 0
+	case types.SeverityInfo:
+		return 1
+	case types.SeverityWarn:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,81 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &JUnitReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.HasPrefix(output.String(), xml.Header) {
+		t.Errorf("Expected the XML header, got: %s", output.String())
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(output.Bytes(), &suites); err != nil {
+		t.Fatalf("Failed to parse JUnit output: %v\n%s", err, output.String())
+	}
+	if suites.Tests != 4 || suites.Failures != 2 || suites.Errors != 1 || len(suites.Suites) != 1 {
+		t.Fatalf("Expected 4 tests with 2 failures and 1 error in one suite, got: %s", output.String())
+	}
+	suite := suites.Suites[0]
+	if suite.Name != "api/openrpc.json" || len(suite.TestCases) != 4 {
+		t.Fatalf("Expected a suite for api/openrpc.json with 4 testcases, got: %s", output.String())
+	}
+
+	broken := suite.TestCases[0]
+	if broken.Name != "broken" || broken.Failure != nil || broken.Error == nil ||
+		broken.Error.Type != "rule execution error" || broken.Error.Message != "unknown function: nope" {
+		t.Errorf("Expected broken to be a rule execution error, got: %+v", broken)
+	}
+
+	if passed := suite.TestCases[1]; passed.Name != "info-title" || passed.Failure != nil || passed.Error != nil {
+		t.Errorf("Expected info-title to pass, got: %+v", passed)
+	}
+
+	// The suppressed method-docs result is not counted.
+	warning := suite.TestCases[2].Failure
+	if suite.TestCases[2].Name != "method-docs" || warning == nil {
+		t.Fatalf("Expected method-docs to fail, got: %+v", suite.TestCases[2])
+	}
+	want := "api/openrpc.json:4:5 $.methods[0].description: Missing required field 'description' at $.methods[0]"
+	if warning.Message != "1 violation(s)" || warning.Type != "warn" || warning.Text != want {
+		t.Errorf("Unexpected failure: %+v", warning)
+	}
+
+	// Neither is the baselined param-name result, and the message survives
+	// XML escaping.
+	failure := suite.TestCases[3].Failure
+	if suite.TestCases[3].Name != "param-name" || failure == nil {
+		t.Fatalf("Expected param-name to fail, got: %+v", suite.TestCases[3])
+	}
+	want = "api/openrpc.json:4:41 $.methods[0].params[0].name: " + testResults()[1].Message
+	if failure.Message != "1 violation(s)" || failure.Type != "error" || failure.Text != want {
+		t.Errorf("Unexpected failure: %+v", failure)
+	}
+}
+
+func TestJUnitReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &JUnitReporter{}
+	if err := reporter.Format(nil, 3, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(output.Bytes(), &suites); err != nil {
+		t.Fatalf("Failed to parse JUnit output: %v\n%s", err, output.String())
+	}
+	if suites.Tests != 3 || suites.Failures != 0 || suites.Errors != 0 || len(suites.Suites) != 1 {
+		t.Fatalf("Expected 3 passing tests in one suite, got: %s", output.String())
+	}
+	if suite := suites.Suites[0]; suite.Name != "openrpc-linter" || suite.Tests != 3 || len(suite.TestCases) != 0 {
+		t.Errorf("Expected an openrpc-linter suite counting 3 tests, got: %+v", suite)
+	}
+}
@@ -0,0 +1,74 @@
+package reporters
+
+import (
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// MarkdownReporter writes a Markdown report for build artifacts and pull
+// request comments: a summary table by rule and severity, rule execution
+// errors, and the results found in each method, linked to rule
+// documentation where the rule has it.
+type MarkdownReporter struct {
+	Run *Run
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(template.FuncMap{
+	"cell": markdownCell,
+	"code": markdownCode,
+	"icon": severityIcon,
+}).Parse(`# OpenRPC lint report
+
+{{if .File}}` + "`{{.File}}`" + ` · {{end}}{{.TotalRules}} rules{{if .ToolVersion}} · openrpc-linter {{.ToolVersion}}{{end}}
+
+{{if .Counts.Total}}**{{.Counts.Total}} problem(s): {{.Counts.Errors}} error(s), {{.Counts.Warnings}} warning(s), {{.Counts.Infos}} info**{{else if not .ExecutionErrors}}**✅ No problems found**{{end}}{{if .Skipped}} ({{.Skipped}} suppressed or baselined){{end}}
+{{- if .ExecutionErrors}}
+
+**⛔ {{len .ExecutionErrors}} rule(s) failed to run; check the rules configuration**
+{{- end}}
+
+## Rules
+
+| Rule | Severity | Errors | Warnings | Info | Description |
+| --- | --- | ---: | ---: | ---: | --- |
+{{range .Rules}}| {{if .Failed}}⛔{{else if .Counts.Total}}{{icon .Severity}}{{else}}✅{{end}} {{template "rule" .}} | {{.Severity}} | {{.Counts.Errors}} | {{.Counts.Warnings}} | {{.Counts.Infos}} | {{cell .Description}} |
+{{end}}
+{{- if .ExecutionErrors}}
+## Rule execution errors
+
+{{range .ExecutionErrors}}- ` + "`{{.RuleID}}`" + `: {{cell .Message}}
+{{end}}
+{{- end}}
+{{- if .Methods}}
+## Results by method
+{{range .Methods}}
+### {{if .Name}}` + "`{{.Name}}`" + `{{else}}Outside methods{{end}}
+
+| Severity | Rule | Message | Location |
+| --- | --- | --- | --- |
+{{range .Results}}| {{icon .Severity}} {{.Severity}} | {{template "rule" .}} | {{cell .Message}} | {{if .Location}}` + "`{{code .Location}}`" + `{{if .JSONPath}} {{end}}{{end}}{{if .JSONPath}}` + "`{{code .JSONPath}}`" + `{{end}} |
+{{end}}
+{{- end}}
+{{- end}}
+{{- define "rule"}}{{if .Documentation}}[{{.RuleID}}]({{.Documentation}}){{else}}` + "`{{.RuleID}}`" + `{{end}}{{end}}`))
+
+func (r *MarkdownReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	return markdownTemplate.Execute(output, summarize(results, totalRules, r.Run))
+}
+
+// markdownCell escapes s for use in a Markdown table cell, keeping
+// renderers from treating anything in it as HTML.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownCode escapes s for use in a code span in a Markdown table cell,
+// where HTML is not interpreted but the cell separator still is.
+func markdownCode(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,115 @@
+package reporters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestMarkdownReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &MarkdownReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Suppressed and baselined results are only counted, and the '|' and
+	// '<' in messages and descriptions cannot break out of their cells.
+	expected := "# OpenRPC lint report\n" +
+		"\n" +
+		"`api/openrpc.json` · 4 rules · openrpc-linter 1.2.3\n" +
+		"\n" +
+		"**2 problem(s): 1 error(s), 1 warning(s), 0 info** (2 suppressed or baselined)\n" +
+		"\n" +
+		"**⛔ 1 rule(s) failed to run; check the rules configuration**\n" +
+		"\n" +
+		"## Rules\n" +
+		"\n" +
+		"| Rule | Severity | Errors | Warnings | Info | Description |\n" +
+		"| --- | --- | ---: | ---: | ---: | --- |\n" +
+		"| ⛔ `broken` | error | 0 | 0 | 0 |  |\n" +
+		"| ✅ `info-title` | error | 0 | 0 | 0 |  |\n" +
+		"| ⚠️  [method-docs](https://example.com/rules/method-docs) | warn | 0 | 1 | 0 | Methods must have &lt;descriptions> |\n" +
+		"| ❌ `param-name` | error | 1 | 0 | 0 | Param names must be identifiers |\n" +
+		"\n" +
+		"## Rule execution errors\n" +
+		"\n" +
+		"- `broken`: unknown function: nope\n" +
+		"\n" +
+		"## Results by method\n" +
+		"\n" +
+		"### `foo`\n" +
+		"\n" +
+		"| Severity | Rule | Message | Location |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| ⚠️  warn | [method-docs](https://example.com/rules/method-docs) | Missing required field 'description' at $.methods[0] | `api/openrpc.json:4:5` `$.methods[0].description` |\n" +
+		"| ❌ error | `param-name` | \"a&b\" must match &lt;^\\w+$> \\| 100%, really: yes | `api/openrpc.json:4:41` `$.methods[0].params[0].name` |\n"
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestMarkdownReporterGroupsByMethod(t *testing.T) {
+	run := &Run{
+		Document: map[string]interface{}{
+			"methods": []interface{}{
+				map[string]interface{}{"name": "foo"},
+				map[string]interface{}{"name": "bar"},
+			},
+		},
+	}
+	results := []types.RuleFunctionResult{
+		{
+			RuleID:   "param-description",
+			Severity: types.SeverityWarn,
+			Message:  "Missing required field 'description'\nat the shared param",
+			Path:     []string{"components", "contentDescriptors", "Shared"},
+			Related:  [][]string{{"methods", "0", "params", "0"}, {"methods", "1", "params", "0"}},
+		},
+		{
+			RuleID:   "info-description",
+			Severity: types.SeverityInfo,
+			Message:  "Missing required field 'description' at $.info",
+			Path:     []string{"info"},
+		},
+	}
+
+	var output bytes.Buffer
+	reporter := &MarkdownReporter{Run: run}
+	if err := reporter.Format(results, 2, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// A shared definition is listed under every method that uses it, and
+	// results outside any method come last.
+	row := "| ⚠️  warn | `param-description` | Missing required field 'description' at the shared param | `$.components.contentDescriptors.Shared` |\n"
+	for _, expected := range []string{
+		"### `foo`\n\n| Severity | Rule | Message | Location |\n| --- | --- | --- | --- |\n" + row,
+		"### `bar`\n\n| Severity | Rule | Message | Location |\n| --- | --- | --- | --- |\n" + row,
+		"### Outside methods\n",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("Expected %q in the output, got:\n%s", expected, output.String())
+		}
+	}
+	if foo, outside := strings.Index(output.String(), "### `foo`"), strings.Index(output.String(), "### Outside methods"); outside < foo {
+		t.Errorf("Expected results outside methods last, got:\n%s", output.String())
+	}
+}
+
+func TestMarkdownReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &MarkdownReporter{}
+	if err := reporter.Format(nil, 3, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	if !strings.Contains(output.String(), "**✅ No problems found**") {
+		t.Errorf("Expected no problems, got:\n%s", output.String())
+	}
+	if strings.Contains(output.String(), "## Results by method") || strings.Contains(output.String(), "## Rule execution errors") {
+		t.Errorf("Expected no result sections, got:\n%s", output.String())
+	}
+}
@@ -0,0 +1,63 @@
+package reporters
+
+import (
+	"sort"
+	"sync"
+)
+
+// ReporterFactory creates a reporter for a lint run.
+type ReporterFactory func(run *Run) Reporter
+
+var (
+	registryMu       sync.RWMutex
+	reporterRegistry = make(map[string]ReporterFactory)
+)
+
+func init() {
+	RegisterReporters()
+}
+
+func RegisterReporters() {
+	RegisterReporter("text", func(run *Run) Reporter { return &TextReporter{} })
+	RegisterReporter("stylish", func(run *Run) Reporter { return &StylishReporter{Run: run} })
+	RegisterReporter("json", func(run *Run) Reporter { return &JSONReporter{Run: run} })
+	RegisterReporter("sarif", func(run *Run) Reporter { return &SARIFReporter{Run: run} })
+	RegisterReporter("junit", func(run *Run) Reporter { return &JUnitReporter{Run: run} })
+	RegisterReporter("github", func(run *Run) Reporter { return &GitHubReporter{} })
+	RegisterReporter("gitlab", func(run *Run) Reporter { return &GitLabReporter{} })
+	RegisterReporter("checkstyle", func(run *Run) Reporter { return &CheckstyleReporter{Run: run} })
+	RegisterReporter("tap", func(run *Run) Reporter { return &TAPReporter{Run: run} })
+	RegisterReporter("markdown", func(run *Run) Reporter { return &MarkdownReporter{Run: run} })
+	RegisterReporter("html", func(run *Run) Reporter { return &HTMLReporter{Run: run} })
+}
+
+// RegisterReporter adds or replaces the reporter for the given format name.
+func RegisterReporter(name string, factory ReporterFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	reporterRegistry[name] = factory
+}
+
+// GetReporter returns a reporter for the given format name, or nil if there
+// is none.
+func GetReporter(name string, run *Run) Reporter {
+	registryMu.RLock()
+	factory := reporterRegistry[name]
+	registryMu.RUnlock()
+	if factory == nil {
+		return nil
+	}
+	return factory(run)
+}
+
+// ReporterNames returns the registered format names, sorted.
+func ReporterNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(reporterRegistry))
+	for name := range reporterRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
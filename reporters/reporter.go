@@ -2,6 +2,7 @@ package reporters
 
 import (
 	"io"
+	"sort"
 
 	"github.com/shanejonas/openrpc-linter/types"
 )
@@ -9,3 +10,46 @@ import (
 type Reporter interface {
 	Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error
 }
+
+// Run describes the lint run a report is for, for reporters that need more
+// than the results themselves.
+type Run struct {
+	ToolVersion string
+	RulesFile   string                // Source of the ruleset
+	File        string                // The linted document
+	Source      []byte                // Contents of File
+	Document    interface{}           // File parsed, with $refs intact
+	Rules       map[string]types.Rule // Rules applied to File, by ID
+}
+
+// ruleIDs returns the IDs of the run's rules and of any other rule with a
+// result, sorted.
+func (r *Run) ruleIDs(results []types.RuleFunctionResult) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if r != nil {
+		for id := range r.Rules {
+			add(id)
+		}
+	}
+	for _, result := range results {
+		add(result.RuleID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// rule returns the rule with the given ID, or an empty rule if the run does
+// not have it.
+func (r *Run) rule(id string) types.Rule {
+	if r == nil {
+		return types.Rule{}
+	}
+	return r.Rules[id]
+}
@@ -0,0 +1,94 @@
+package reporters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+const testSource = `{
+  "info": {"title": "Test API", "version": "1.0.0"},
+  "methods": [
+    {"name": "foo", "params": [{"name": "a&b"}]}
+  ]
+}`
+
+// testRun returns the run the reporter tests format results for: four
+// rules, one of which has no results.
+func testRun(t *testing.T) *Run {
+	t.Helper()
+	var document interface{}
+	if err := json.Unmarshal([]byte(testSource), &document); err != nil {
+		t.Fatalf("Failed to parse test document: %v", err)
+	}
+	return &Run{
+		ToolVersion: "1.2.3",
+		RulesFile:   "rules.yml",
+		File:        "api/openrpc.json",
+		Source:      []byte(testSource),
+		Document:    document,
+		Rules: map[string]types.Rule{
+			"broken":      {},
+			"info-title":  {},
+			"param-name":  {Description: "Param names must be identifiers"},
+			"method-docs": {Description: "Methods must have <descriptions>", Documentation: "https://example.com/rules/method-docs", Severity: types.SeverityWarn},
+		},
+	}
+}
+
+// testResults returns one result of each kind: a warning, an error whose
+// message needs escaping in every format, a suppressed and a baselined
+// result, and a rule execution error.
+func testResults() []types.RuleFunctionResult {
+	return []types.RuleFunctionResult{
+		{
+			RuleID:      "method-docs",
+			Severity:    types.SeverityWarn,
+			Kind:        types.KindViolation,
+			Message:     "Missing required field 'description' at $.methods[0]",
+			Path:        []string{"methods", "0", "description"},
+			File:        "api/openrpc.json",
+			Range:       &types.Range{Start: types.Position{Line: 4, Column: 5}, End: types.Position{Line: 4, Column: 49}},
+			Fingerprint: "fp-method-docs",
+		},
+		{
+			RuleID:      "param-name",
+			Severity:    types.SeverityError,
+			Kind:        types.KindViolation,
+			Message:     `"a&b" must match <^\w+$> | 100%, really: yes`,
+			Path:        []string{"methods", "0", "params", "0", "name"},
+			File:        "api/openrpc.json",
+			Range:       &types.Range{Start: types.Position{Line: 4, Column: 41}, End: types.Position{Line: 4, Column: 46}},
+			Fingerprint: "fp-param-name",
+		},
+		{
+			RuleID:            "method-docs",
+			Severity:          types.SeverityWarn,
+			Kind:              types.KindViolation,
+			Message:           "Missing required field 'summary' at $.methods[0]",
+			Path:              []string{"methods", "0", "summary"},
+			File:              "api/openrpc.json",
+			Range:             &types.Range{Start: types.Position{Line: 4, Column: 5}, End: types.Position{Line: 4, Column: 49}},
+			Suppressed:        true,
+			SuppressionReason: "Legacy method",
+		},
+		{
+			RuleID:    "param-name",
+			Severity:  types.SeverityError,
+			Kind:      types.KindViolation,
+			Message:   "Old finding",
+			Path:      []string{"info"},
+			File:      "api/openrpc.json",
+			Range:     &types.Range{Start: types.Position{Line: 2, Column: 11}, End: types.Position{Line: 2, Column: 53}},
+			Baselined: true,
+		},
+		{
+			RuleID:   "broken",
+			Severity: types.SeverityError,
+			Kind:     types.KindError,
+			Message:  "unknown function: nope",
+			File:     "api/openrpc.json",
+		},
+	}
+}
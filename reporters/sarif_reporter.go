@@ -0,0 +1,235 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "openrpc-linter"
+	toolURI      = "https://github.com/shanejonas/openrpc-linter"
+
+	// sarifFingerprintKey names the fingerprint algorithm in SARIF results,
+	// versioned so that a change to types.Fingerprint can be told apart.
+	sarifFingerprintKey = "openrpc-linter/v1"
+)
+
+// SARIFReporter writes results as a SARIF 2.1.0 log, for code scanning
+// dashboards. Rule execution errors are reported as tool execution
+// notifications rather than results.
+type SARIFReporter struct {
+	Run *Run
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     *sarifMessage      `json:"shortDescription,omitempty"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level          string              `json:"level"`
+	Message        sarifMessage        `json:"message"`
+	AssociatedRule *sarifRuleReference `json:"associatedRule,omitempty"`
+}
+
+type sarifRuleReference struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+}
+
+type sarifResult struct {
+	RuleID           string             `json:"ruleId"`
+	RuleIndex        int                `json:"ruleIndex"`
+	Level            string             `json:"level"`
+	Message          sarifMessage       `json:"message"`
+	Locations        []sarifLocation    `json:"locations,omitempty"`
+	RelatedLocations []sarifLocation    `json:"relatedLocations,omitempty"`
+	Fingerprints     map[string]string  `json:"fingerprints,omitempty"`
+	Suppressions     []sarifSuppression `json:"suppressions,omitempty"`
+	BaselineState    string             `json:"baselineState,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                   `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+func (r *SARIFReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	ruleIDs := r.Run.ruleIDs(results)
+	ruleIndex := make(map[string]int, len(ruleIDs))
+	rules := make([]sarifRule, len(ruleIDs))
+	for i, id := range ruleIDs {
+		ruleIndex[id] = i
+		rule := r.Run.rule(id)
+		rules[i] = sarifRule{
+			ID:                   id,
+			HelpURI:              rule.Documentation,
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		}
+		if rule.Description != "" {
+			rules[i].ShortDescription = &sarifMessage{Text: rule.Description}
+		}
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			InformationURI: toolURI,
+			Rules:          rules,
+		}},
+		Invocations: []sarifInvocation{{ExecutionSuccessful: true}},
+		Results:     []sarifResult{},
+	}
+	if r.Run != nil {
+		run.Tool.Driver.Version = r.Run.ToolVersion
+	}
+
+	for _, result := range results {
+		if result.IsError() {
+			run.Invocations[0].ExecutionSuccessful = false
+			run.Invocations[0].ToolExecutionNotifications = append(run.Invocations[0].ToolExecutionNotifications, sarifNotification{
+				Level:          "error",
+				Message:        sarifMessage{Text: result.Message},
+				AssociatedRule: &sarifRuleReference{ID: result.RuleID, Index: ruleIndex[result.RuleID]},
+			})
+			continue
+		}
+
+		sr := sarifResult{
+			RuleID:    result.RuleID,
+			RuleIndex: ruleIndex[result.RuleID],
+			Level:     sarifLevel(result.Severity),
+			Message:   sarifMessage{Text: result.Message},
+			Locations: []sarifLocation{sarifResultLocation(result)},
+		}
+		if result.Fingerprint != "" {
+			sr.Fingerprints = map[string]string{sarifFingerprintKey: result.Fingerprint}
+		}
+		for i, related := range result.Related {
+			id := i
+			sr.RelatedLocations = append(sr.RelatedLocations, sarifLocation{
+				ID:               &id,
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: types.JSONPath(related), Kind: "member"}},
+			})
+		}
+		if result.Suppressed {
+			sr.Suppressions = []sarifSuppression{{Kind: "inSource", Justification: result.SuppressionReason}}
+		}
+		if result.Baselined {
+			sr.BaselineState = "unchanged"
+		}
+		run.Results = append(run.Results, sr)
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifResultLocation(result types.RuleFunctionResult) sarifLocation {
+	var location sarifLocation
+	if result.File != "" {
+		location.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: types.FingerprintFile(result.File)},
+		}
+		if result.Range != nil {
+			location.PhysicalLocation.Region = &sarifRegion{
+				StartLine:   result.Range.Start.Line,
+				StartColumn: result.Range.Start.Column,
+				EndLine:     result.Range.End.Line,
+				EndColumn:   result.Range.End.Column,
+			}
+		}
+	}
+	if len(result.Path) > 0 {
+		location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: types.JSONPath(result.Path), Kind: "member"}}
+	}
+	return location
+}
+
+// sarifLevel maps a rule severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case types.SeverityWarn:
+		return "warning"
+	case types.SeverityInfo, types.SeverityHint:
+		return "note"
+	case types.SeverityOff:
+		return "none"
+	default:
+		return "error"
+	}
+}
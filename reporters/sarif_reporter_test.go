@@ -0,0 +1,133 @@
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSARIFReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &SARIFReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(output.Bytes(), &log); err != nil {
+		t.Fatalf("Failed to parse SARIF output: %v\n%s", err, output.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Expected a single SARIF 2.1.0 run, got: %s", output.String())
+	}
+	run := log.Runs[0]
+
+	if run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("Expected driver version 1.2.3, got %q", run.Tool.Driver.Version)
+	}
+	var ids []string
+	for _, rule := range run.Tool.Driver.Rules {
+		ids = append(ids, rule.ID)
+	}
+	if want := []string{"broken", "info-title", "method-docs", "param-name"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Expected rules %v, got %v", want, ids)
+	}
+	rule := run.Tool.Driver.Rules[2]
+	if rule.ShortDescription == nil || rule.ShortDescription.Text != "Methods must have <descriptions>" ||
+		rule.HelpURI != "https://example.com/rules/method-docs" || rule.DefaultConfiguration.Level != "warning" {
+		t.Errorf("Unexpected rule metadata: %+v", rule)
+	}
+
+	if len(run.Invocations) != 1 || run.Invocations[0].ExecutionSuccessful {
+		t.Fatalf("Expected a single failed invocation, got: %+v", run.Invocations)
+	}
+	notifications := run.Invocations[0].ToolExecutionNotifications
+	if len(notifications) != 1 || notifications[0].Message.Text != "unknown function: nope" ||
+		notifications[0].AssociatedRule == nil || *notifications[0].AssociatedRule != (sarifRuleReference{ID: "broken", Index: 0}) {
+		t.Errorf("Expected the broken rule as an execution notification, got: %+v", notifications)
+	}
+
+	// The rule error is a notification, not a result.
+	if len(run.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(run.Results))
+	}
+
+	warning := run.Results[0]
+	if warning.RuleID != "method-docs" || warning.RuleIndex != 2 || warning.Level != "warning" {
+		t.Errorf("Unexpected result: %+v", warning)
+	}
+	location := warning.Locations[0]
+	if location.PhysicalLocation == nil || location.PhysicalLocation.ArtifactLocation.URI != "api/openrpc.json" ||
+		location.PhysicalLocation.Region == nil || *location.PhysicalLocation.Region != (sarifRegion{StartLine: 4, StartColumn: 5, EndLine: 4, EndColumn: 49}) {
+		t.Errorf("Unexpected physical location: %+v", location.PhysicalLocation)
+	}
+	if len(location.LogicalLocations) != 1 || location.LogicalLocations[0].FullyQualifiedName != "$.methods[0].description" {
+		t.Errorf("Expected logical location $.methods[0].description, got %+v", location.LogicalLocations)
+	}
+	if warning.Fingerprints["openrpc-linter/v1"] != "fp-method-docs" {
+		t.Errorf("Expected the result fingerprint, got %v", warning.Fingerprints)
+	}
+	if warning.Suppressions != nil || warning.BaselineState != "" {
+		t.Errorf("Expected an active result, got %+v", warning)
+	}
+
+	escaped := run.Results[1]
+	if want := testResults()[1].Message; escaped.Message.Text != want || escaped.Level != "error" {
+		t.Errorf("Expected message %q at level error, got %q at %q", want, escaped.Message.Text, escaped.Level)
+	}
+
+	suppressed := run.Results[2]
+	if want := []sarifSuppression{{Kind: "inSource", Justification: "Legacy method"}}; !reflect.DeepEqual(suppressed.Suppressions, want) {
+		t.Errorf("Expected suppressions %+v, got %+v", want, suppressed.Suppressions)
+	}
+
+	baselined := run.Results[3]
+	if baselined.BaselineState != "unchanged" || baselined.Suppressions != nil {
+		t.Errorf("Expected an unchanged baseline state, got %+v", baselined)
+	}
+}
+
+func TestSARIFReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &SARIFReporter{}
+	if err := reporter.Format(nil, 0, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Empty lists are written as [] rather than null.
+	for _, want := range []string{`"rules": []`, `"results": []`, `"executionSuccessful": true`} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected %s in the output, got: %s", want, output.String())
+		}
+	}
+}
+
+func TestSARIFReporterAbsolutePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	results := testResults()[:1]
+	results[0].File = filepath.Join(wd, "api", "openrpc.json")
+
+	var output bytes.Buffer
+	reporter := &SARIFReporter{Run: testRun(t)}
+	if err := reporter.Format(results, 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(output.Bytes(), &log); err != nil {
+		t.Fatalf("Failed to parse SARIF output: %v\n%s", err, output.String())
+	}
+
+	// Code scanning maps artifacts to files in the repository, so an
+	// absolute path is made relative to the working directory.
+	if uri := log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "api/openrpc.json" {
+		t.Errorf("Expected artifact api/openrpc.json, got %s", uri)
+	}
+}
@@ -0,0 +1,236 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// Lines of source shown around a result, and the most lines of the result's
+// own range shown in its code frame.
+const (
+	frameContext  = 1
+	frameMaxLines = 4
+)
+
+const (
+	ansiReset     = "\033[0m"
+	ansiBold      = "\033[1m"
+	ansiDim       = "\033[2m"
+	ansiUnderline = "\033[4m"
+	ansiRed       = "\033[31m"
+	ansiYellow    = "\033[33m"
+	ansiCyan      = "\033[36m"
+)
+
+// StylishReporter writes results grouped by file, each with its line and
+// column, severity and rule ID, followed by a frame of the source lines it
+// was found on. Output is colored when it goes to a terminal, unless the
+// NO_COLOR environment variable is set.
+type StylishReporter struct {
+	Run *Run
+}
+
+func (r *StylishReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	color := useColor(output)
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	var lines []string
+	if r.Run != nil && r.Run.Source != nil {
+		lines = strings.Split(strings.ReplaceAll(string(r.Run.Source), "\r\n", "\n"), "\n")
+	}
+
+	var files []string
+	fileResults := make(map[string][]types.RuleFunctionResult)
+	var executionErrors []types.RuleFunctionResult
+	counts := make(map[string]int)
+	skipped := 0
+	for _, result := range results {
+		if result.IsError() {
+			executionErrors = append(executionErrors, result)
+			continue
+		}
+		if result.Suppressed || result.Baselined {
+			skipped++
+			continue
+		}
+		if _, ok := fileResults[result.File]; !ok {
+			files = append(files, result.File)
+		}
+		fileResults[result.File] = append(fileResults[result.File], result)
+		counts[stylishSeverity(result.Severity)]++
+	}
+
+	var sb strings.Builder
+	for _, file := range files {
+		name := file
+		if name == "" {
+			name = "<document>"
+		}
+		sb.WriteString("\n" + paint(ansiBold+ansiUnderline, name) + "\n")
+
+		locWidth := 0
+		for _, result := range fileResults[file] {
+			locWidth = max(locWidth, len(stylishLocation(result)))
+		}
+
+		for i, result := range fileResults[file] {
+			severity := stylishSeverity(result.Severity)
+			fmt.Fprintf(&sb, "  %s  %s  %s  %s\n",
+				paint(ansiDim, fmt.Sprintf("%-*s", locWidth, stylishLocation(result))),
+				paint(severityColor(severity), fmt.Sprintf("%-7s", severity)),
+				result.Message,
+				paint(ansiDim, result.RuleID))
+
+			if result.Range != nil && lines != nil && (r.Run == nil || file == r.Run.File) {
+				sb.WriteString(codeFrame(lines, *result.Range, paint))
+				if i < len(fileResults[file])-1 {
+					sb.WriteString("\n")
+				}
+			}
+		}
+	}
+
+	if len(executionErrors) > 0 {
+		sb.WriteString("\n" + paint(ansiBold+ansiUnderline, "Rule execution errors") + "\n")
+		for _, result := range executionErrors {
+			fmt.Fprintf(&sb, "  %s  %s  %s\n", paint(ansiRed, "error  "), result.Message, paint(ansiDim, result.RuleID))
+		}
+	}
+
+	sb.WriteString("\n")
+	var summary string
+	problems := counts["error"] + counts["warning"] + counts["info"]
+	if problems > 0 {
+		var parts []string
+		for _, severity := range []string{"error", "warning", "info"} {
+			if counts[severity] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[severity], plural(severity, counts[severity])))
+			}
+		}
+		summaryColor := ansiYellow
+		if counts["error"] > 0 {
+			summaryColor = ansiRed
+		}
+		summary = paint(ansiBold+summaryColor, fmt.Sprintf("✖ %d %s (%s)", problems, plural("problem", problems), strings.Join(parts, ", ")))
+	} else if len(executionErrors) == 0 {
+		summary = paint(ansiBold, fmt.Sprintf("✔ No problems found by %d %s", totalRules, plural("rule", totalRules)))
+	}
+	if skipped > 0 {
+		if summary != "" {
+			summary += " "
+		}
+		summary += paint(ansiDim, fmt.Sprintf("(%d suppressed or baselined)", skipped))
+	}
+	if summary != "" {
+		sb.WriteString(summary + "\n")
+	}
+	if len(executionErrors) > 0 {
+		sb.WriteString(paint(ansiBold+ansiRed, fmt.Sprintf("✖ %d of %d rules failed to run; check the rules configuration", len(executionErrors), totalRules)) + "\n")
+	}
+
+	_, err := io.WriteString(output, sb.String())
+	return err
+}
+
+// codeFrame renders the source lines of r with a line of context either
+// side, marking the lines in r and the column it starts at.
+func codeFrame(lines []string, r types.Range, paint func(code, s string) string) string {
+	start := max(r.Start.Line-frameContext, 1)
+	last := min(r.End.Line, r.Start.Line+frameMaxLines-1)
+	end := min(last+frameContext, len(lines))
+	width := len(fmt.Sprint(end))
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for n := start; n <= end; n++ {
+		line := lines[n-1]
+		marker := " "
+		if n >= r.Start.Line && n <= last {
+			marker = paint(ansiRed+ansiBold, ">")
+		}
+		fmt.Fprintf(&sb, "  %s %s %s\n", marker, paint(ansiDim, fmt.Sprintf("%*d |", width, n)), line)
+
+		if n == r.Start.Line {
+			fmt.Fprintf(&sb, "    %s %s%s\n", paint(ansiDim, strings.Repeat(" ", width)+" |"), caretPadding(line, r.Start.Column), paint(ansiRed+ansiBold, "^"))
+		}
+	}
+	return sb.String()
+}
+
+// caretPadding returns the whitespace that puts a caret under column of
+// line, keeping any tabs so the caret lines up however tabs are displayed.
+func caretPadding(line string, column int) string {
+	var sb strings.Builder
+	for i, c := range line {
+		if utf8.RuneCountInString(line[:i]) >= column-1 {
+			break
+		}
+		if c == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	return sb.String()
+}
+
+func stylishLocation(result types.RuleFunctionResult) string {
+	if result.Range == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%d", result.Range.Start.Line, result.Range.Start.Column)
+}
+
+// stylishSeverity names a result severity for display.
+func stylishSeverity(severity string) string {
+	switch severity {
+	case types.SeverityWarn:
+		return "warning"
+	case types.SeverityInfo, types.SeverityHint:
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+func severityColor(severity string) string {
+	switch severity {
+	case "warning":
+		return ansiYellow
+	case "info":
+		return ansiCyan
+	default:
+		return ansiRed
+	}
+}
+
+func plural(word string, n int) string {
+	if n == 1 || word == "info" {
+		return word
+	}
+	return word + "s"
+}
+
+// useColor reports whether output is a terminal and NO_COLOR is not set.
+// See https://no-color.org.
+func useColor(output io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := output.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,89 @@
+package reporters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestStylishReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &StylishReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Output to a buffer is not colored, and suppressed and baselined
+	// results are only counted.
+	expected := `
+api/openrpc.json
+  4:5   warning  Missing required field 'description' at $.methods[0]  method-docs
+
+    3 |   "methods": [
+  > 4 |     {"name": "foo", "params": [{"name": "a&b"}]}
+      |     ^
+    5 |   ]
+
+  4:41  error    "a&b" must match <^\w+$> | 100%, really: yes  param-name
+
+    3 |   "methods": [
+  > 4 |     {"name": "foo", "params": [{"name": "a&b"}]}
+      |                                         ^
+    5 |   ]
+
+Rule execution errors
+  error    unknown function: nope  broken
+
+✖ 2 problems (1 error, 1 warning) (2 suppressed or baselined)
+✖ 1 of 4 rules failed to run; check the rules configuration
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestStylishReporterCodeFrame(t *testing.T) {
+	run := &Run{File: "openrpc.json", Source: []byte("{\n\t\"methods\": []\n}")}
+	results := []types.RuleFunctionResult{{
+		RuleID:   "methods-not-empty",
+		Severity: types.SeverityError,
+		Message:  "Methods must not be empty",
+		File:     "openrpc.json",
+		Range:    &types.Range{Start: types.Position{Line: 2, Column: 13}, End: types.Position{Line: 2, Column: 15}},
+	}}
+
+	var output bytes.Buffer
+	reporter := &StylishReporter{Run: run}
+	if err := reporter.Format(results, 1, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// The caret line keeps the source's tabs so it lines up.
+	expected := `
+openrpc.json
+  2:13  error    Methods must not be empty  methods-not-empty
+
+    1 | {
+  > 2 | 	"methods": []
+      | 	           ^
+    3 | }
+
+✖ 1 problem (1 error)
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestStylishReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &StylishReporter{}
+	if err := reporter.Format(nil, 3, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	if expected := "\n✔ No problems found by 3 rules\n"; output.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, output.String())
+	}
+}
@@ -0,0 +1,183 @@
+package reporters
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// reportSummary is the view of a run shared by the document-style
+// reporters: counts by rule and severity, and results broken down by the
+// method they were found in.
+type reportSummary struct {
+	File            string
+	ToolVersion     string
+	TotalRules      int
+	Counts          severityCounts
+	Rules           []ruleSummary
+	Methods         []methodSummary
+	ExecutionErrors []types.RuleFunctionResult
+	Skipped         int // Suppressed or baselined results
+}
+
+type severityCounts struct {
+	Errors   int
+	Warnings int
+	Infos    int
+}
+
+func (c *severityCounts) add(severity string) {
+	switch stylishSeverity(severity) {
+	case "warning":
+		c.Warnings++
+	case "info":
+		c.Infos++
+	default:
+		c.Errors++
+	}
+}
+
+func (c severityCounts) Total() int {
+	return c.Errors + c.Warnings + c.Infos
+}
+
+type ruleSummary struct {
+	RuleID        string
+	Description   string
+	Documentation string
+	Severity      string
+	Counts        severityCounts
+	Failed        bool // The rule could not run
+}
+
+// methodSummary holds the results found in one method. Results outside any
+// method are collected under an empty Name.
+type methodSummary struct {
+	Name    string
+	Path    string
+	Results []summaryResult
+	index   int
+}
+
+type summaryResult struct {
+	types.RuleFunctionResult
+	Location      string // file:line:column, if known
+	JSONPath      string
+	Documentation string
+}
+
+// summarize builds the summary of results. Methods are named from the
+// run's document, when it has one, and listed in document order. A result
+// for a shared definition is listed under every method that uses it.
+func summarize(results []types.RuleFunctionResult, totalRules int, run *Run) reportSummary {
+	summary := reportSummary{TotalRules: totalRules}
+	var document interface{}
+	if run != nil {
+		summary.File = run.File
+		summary.ToolVersion = run.ToolVersion
+		document = run.Document
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, id := range run.ruleIDs(results) {
+		rule := run.rule(id)
+		severity := rule.Severity
+		if severity == "" {
+			severity = types.SeverityError
+		}
+		ruleIndex[id] = len(summary.Rules)
+		summary.Rules = append(summary.Rules, ruleSummary{
+			RuleID:        id,
+			Description:   rule.Description,
+			Documentation: rule.Documentation,
+			Severity:      severity,
+		})
+	}
+
+	methodIndex := make(map[string]int)
+	methodFor := func(key string) int {
+		if i, ok := methodIndex[key]; ok {
+			return i
+		}
+		// Results outside any method sort last
+		method := methodSummary{index: int(^uint(0) >> 1)}
+		if key != "" {
+			method.Path = "$.methods[" + key + "]"
+			method.Name = methodName(document, key)
+			method.index, _ = strconv.Atoi(key)
+		}
+		methodIndex[key] = len(summary.Methods)
+		summary.Methods = append(summary.Methods, method)
+		return methodIndex[key]
+	}
+
+	for _, result := range results {
+		if result.IsError() {
+			summary.ExecutionErrors = append(summary.ExecutionErrors, result)
+			summary.Rules[ruleIndex[result.RuleID]].Failed = true
+			continue
+		}
+		if result.Suppressed || result.Baselined {
+			summary.Skipped++
+			continue
+		}
+
+		summary.Counts.add(result.Severity)
+		summary.Rules[ruleIndex[result.RuleID]].Counts.add(result.Severity)
+
+		entry := summaryResult{
+			RuleFunctionResult: result,
+			Documentation:      run.rule(result.RuleID).Documentation,
+		}
+		entry.Location = sourceLocation(result)
+		if len(result.Path) > 0 {
+			entry.JSONPath = types.JSONPath(result.Path)
+		}
+
+		for _, key := range methodKeys(result) {
+			i := methodFor(key)
+			summary.Methods[i].Results = append(summary.Methods[i].Results, entry)
+		}
+	}
+
+	sort.SliceStable(summary.Methods, func(i, j int) bool {
+		return summary.Methods[i].index < summary.Methods[j].index
+	})
+	return summary
+}
+
+// methodKeys returns the indices of the methods a result was found in,
+// either directly or through a usage of a shared definition, or a single
+// empty key if it is outside every method.
+func methodKeys(result types.RuleFunctionResult) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, path := range append([][]string{result.Path}, result.Related...) {
+		if len(path) >= 2 && path[0] == "methods" && !seen[path[1]] {
+			seen[path[1]] = true
+			keys = append(keys, path[1])
+		}
+	}
+	if len(keys) == 0 {
+		return []string{""}
+	}
+	return keys
+}
+
+// methodName returns the name of the method at index in document, falling
+// back to its JSONPath.
+func methodName(document interface{}, index string) string {
+	if doc, ok := document.(map[string]interface{}); ok {
+		if methods, ok := doc["methods"].([]interface{}); ok {
+			if i, err := strconv.Atoi(index); err == nil && i >= 0 && i < len(methods) {
+				if method, ok := methods[i].(map[string]interface{}); ok {
+					if name, ok := method["name"].(string); ok && name != "" {
+						return name
+					}
+				}
+			}
+		}
+	}
+	return "$.methods[" + index + "]"
+}
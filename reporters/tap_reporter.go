@@ -0,0 +1,85 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TAPReporter writes results in the Test Anything Protocol, version 13, with
+// one test point per rule. A rule with violations or that could not run is
+// not ok, and its results are listed in the test point's YAML diagnostics.
+// Suppressed and baselined results do not fail their rule.
+type TAPReporter struct {
+	Run *Run
+}
+
+type tapDiagnostic struct {
+	Message  string `yaml:"message"`
+	Severity string `yaml:"severity"`
+	File     string `yaml:"file,omitempty"`
+	Line     int    `yaml:"line,omitempty"`
+	Column   int    `yaml:"column,omitempty"`
+	Path     string `yaml:"path,omitempty"`
+}
+
+func (r *TAPReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
+	ruleResults := make(map[string][]types.RuleFunctionResult)
+	for _, result := range results {
+		if result.Suppressed || result.Baselined {
+			continue
+		}
+		ruleResults[result.RuleID] = append(ruleResults[result.RuleID], result)
+	}
+
+	ruleIds := r.Run.ruleIDs(results)
+	if _, err := fmt.Fprintf(output, "TAP version 13\n1..%d\n", len(ruleIds)); err != nil {
+		return err
+	}
+
+	for i, ruleId := range ruleIds {
+		failed := ruleResults[ruleId]
+		if len(failed) == 0 {
+			if _, err := fmt.Fprintf(output, "ok %d - %s\n", i+1, ruleId); err != nil {
+				return err
+			}
+			continue
+		}
+
+		diagnostics := make([]tapDiagnostic, len(failed))
+		for j, result := range failed {
+			diagnostics[j] = tapDiagnostic{
+				Message:  result.Message,
+				Severity: result.Severity,
+				File:     result.File,
+			}
+			if result.IsError() {
+				diagnostics[j].Severity = "rule execution error"
+			}
+			if result.Range != nil {
+				diagnostics[j].Line = result.Range.Start.Line
+				diagnostics[j].Column = result.Range.Start.Column
+			}
+			if len(result.Path) > 0 {
+				diagnostics[j].Path = types.JSONPath(result.Path)
+			}
+		}
+
+		var block strings.Builder
+		encoder := yaml.NewEncoder(&block)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(map[string][]tapDiagnostic{"results": diagnostics}); err != nil {
+			return err
+		}
+		lines := strings.Split(strings.TrimSuffix(block.String(), "\n"), "\n")
+		if _, err := fmt.Fprintf(output, "not ok %d - %s\n  ---\n  %s\n  ...\n", i+1, ruleId, strings.Join(lines, "\n  ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package reporters
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTAPReporter(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &TAPReporter{Run: testRun(t)}
+	if err := reporter.Format(testResults(), 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	// Suppressed and baselined results are left out, and messages are
+	// quoted where YAML needs it.
+	expected := `TAP version 13
+1..4
+not ok 1 - broken
+  ---
+  results:
+    - message: 'unknown function: nope'
+      severity: rule execution error
+      file: api/openrpc.json
+  ...
+ok 2 - info-title
+not ok 3 - method-docs
+  ---
+  results:
+    - message: Missing required field 'description' at $.methods[0]
+      severity: warn
+      file: api/openrpc.json
+      line: 4
+      column: 5
+      path: $.methods[0].description
+  ...
+not ok 4 - param-name
+  ---
+  results:
+    - message: '"a&b" must match <^\w+$> | 100%, really: yes'
+      severity: error
+      file: api/openrpc.json
+      line: 4
+      column: 41
+      path: $.methods[0].params[0].name
+  ...
+`
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestTAPReporterEmpty(t *testing.T) {
+	var output bytes.Buffer
+	reporter := &TAPReporter{Run: testRun(t)}
+	if err := reporter.Format(nil, 4, &output); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	expected := "TAP version 13\n1..4\nok 1 - broken\nok 2 - info-title\nok 3 - method-docs\nok 4 - param-name\n"
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
@@ -3,6 +3,7 @@ package reporters
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/shanejonas/openrpc-linter/types"
 )
@@ -11,33 +12,117 @@ type TextReporter struct{}
 
 func (r *TextReporter) Format(results []types.RuleFunctionResult, totalRules int, output io.Writer) error {
 	errorCount := 0
-	ruleErrors := make(map[string][]string)
+	warningCount := 0
+	infoCount := 0
+	suppressedCount := 0
+	baselinedCount := 0
+	ruleErrors := make(map[string][]types.RuleFunctionResult)
+	var ruleIds []string
+	var executionErrors []types.RuleFunctionResult
 
 	for _, result := range results {
+		if result.IsError() {
+			executionErrors = append(executionErrors, result)
+			continue
+		}
+		if result.Suppressed {
+			suppressedCount++
+			continue
+		}
+		if result.Baselined {
+			baselinedCount++
+			continue
+		}
 		if result.Message != "" {
-			ruleErrors[result.RuleID] = append(ruleErrors[result.RuleID], result.Message)
-			errorCount++
+			if _, ok := ruleErrors[result.RuleID]; !ok {
+				ruleIds = append(ruleIds, result.RuleID)
+			}
+			ruleErrors[result.RuleID] = append(ruleErrors[result.RuleID], result)
+			switch result.Severity {
+			case types.SeverityWarn:
+				warningCount++
+			case types.SeverityInfo, types.SeverityHint:
+				infoCount++
+			default:
+				errorCount++
+			}
 		}
 	}
 
-	for ruleId, messages := range ruleErrors {
-		for _, message := range messages {
-			if _, err := fmt.Fprintf(output, "❌ %s: %s\n", ruleId, message); err != nil {
+	for _, ruleId := range ruleIds {
+		for _, result := range ruleErrors[ruleId] {
+			if _, err := fmt.Fprintf(output, "%s %s: %s\n", severityIcon(result.Severity), ruleId, result.Message); err != nil {
 				return err
 			}
 		}
 	}
 
-	if errorCount == 0 {
-		if _, err := fmt.Fprintf(output, "\n✅ All %d rules passed!\n", totalRules); err != nil {
+	if len(executionErrors) > 0 {
+		if _, err := fmt.Fprintf(output, "\nRule execution errors:\n"); err != nil {
 			return err
 		}
-	} else {
-		rulesWithErrors := len(ruleErrors)
-		if _, err := fmt.Fprintf(output, "\n❌ %d error(s) found in %d rules\n", errorCount, rulesWithErrors); err != nil {
+		for _, result := range executionErrors {
+			if _, err := fmt.Fprintf(output, "⛔ %s: %s\n", result.RuleID, result.Message); err != nil {
+				return err
+			}
+		}
+	}
+
+	var counts []string
+	if warningCount > 0 {
+		counts = append(counts, fmt.Sprintf("%d warning(s)", warningCount))
+	}
+	if infoCount > 0 {
+		counts = append(counts, fmt.Sprintf("%d info", infoCount))
+	}
+
+	var skipped []string
+	if suppressedCount > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d suppressed", suppressedCount))
+	}
+	if baselinedCount > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d baselined", baselinedCount))
+	}
+	skippedNote := ""
+	if len(skipped) > 0 {
+		skippedNote = " (" + strings.Join(skipped, ", ") + ")"
+	}
+
+	rulesWithErrors := len(ruleErrors)
+	if errorCount > 0 {
+		summary := fmt.Sprintf("%d error(s)", errorCount)
+		if len(counts) > 0 {
+			summary += ", " + strings.Join(counts, ", ")
+		}
+		if _, err := fmt.Fprintf(output, "\n❌ %s found in %d rules%s\n", summary, rulesWithErrors, skippedNote); err != nil {
+			return err
+		}
+	} else if len(counts) > 0 {
+		if _, err := fmt.Fprintf(output, "\n⚠️  %s found in %d rules%s\n", strings.Join(counts, ", "), rulesWithErrors, skippedNote); err != nil {
+			return err
+		}
+	} else if len(executionErrors) == 0 {
+		if _, err := fmt.Fprintf(output, "\n✅ All %d rules passed!%s\n", totalRules, skippedNote); err != nil {
+			return err
+		}
+	}
+
+	if len(executionErrors) > 0 {
+		if _, err := fmt.Fprintf(output, "\n⛔ %d of %d rules failed to run; check the rules configuration\n", len(executionErrors), totalRules); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+func severityIcon(severity string) string {
+	switch severity {
+	case types.SeverityWarn:
+		return "⚠️ "
+	case types.SeverityInfo, types.SeverityHint:
+		return "ℹ️ "
+	default:
+		return "❌"
+	}
+}
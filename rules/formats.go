@@ -0,0 +1,27 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/shanejonas/openrpc-linter/formats"
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// MatchesFormats reports whether a rule applies to the document, i.e. the
+// rule lists no formats or the document is of at least one of them.
+func MatchesFormats(rule *types.Rule, document interface{}) (bool, error) {
+	if len(rule.Formats) == 0 {
+		return true, nil
+	}
+
+	for _, format := range rule.Formats {
+		detector := formats.GetFormat(format)
+		if detector == nil {
+			return false, fmt.Errorf("unknown format: %s", format)
+		}
+		if detector(document) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
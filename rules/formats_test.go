@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/formats"
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestMatchesFormats(t *testing.T) {
+	formats.RegisterFormat("test-format", func(document interface{}) bool {
+		doc, ok := document.(map[string]interface{})
+		return ok && doc["x-test"] == true
+	})
+
+	tests := []struct {
+		name        string
+		formats     types.StringList
+		document    map[string]interface{}
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:     "no formats",
+			document: map[string]interface{}{"openrpc": "1.2.6"},
+			expected: true,
+		},
+		{
+			name:     "matching version",
+			formats:  types.StringList{"openrpc-1.3"},
+			document: map[string]interface{}{"openrpc": "1.3.2"},
+			expected: true,
+		},
+		{
+			name:     "non-matching version",
+			formats:  types.StringList{"openrpc-1.3"},
+			document: map[string]interface{}{"openrpc": "1.2.6"},
+			expected: false,
+		},
+		{
+			name:     "any of several formats",
+			formats:  types.StringList{"openrpc-1.2", "openrpc-1.3"},
+			document: map[string]interface{}{"openrpc": "1.2.6"},
+			expected: true,
+		},
+		{
+			name:     "custom format",
+			formats:  types.StringList{"test-format"},
+			document: map[string]interface{}{"x-test": true},
+			expected: true,
+		},
+		{
+			name:        "unknown format",
+			formats:     types.StringList{"openrpc-9.9"},
+			document:    map[string]interface{}{"openrpc": "1.2.6"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := MatchesFormats(&types.Rule{Formats: tt.formats}, tt.document)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MatchesFormats() returned error: %v", err)
+			}
+			if matches != tt.expected {
+				t.Errorf("MatchesFormats() = %v, expected %v", matches, tt.expected)
+			}
+		})
+	}
+}
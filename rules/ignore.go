@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IgnoreExtension is the specification extension used to silence findings
+// for an object and everything below it. It may be a rule ID, a list of rule
+// IDs, or a map of rule ID to the reason it is ignored.
+const IgnoreExtension = "x-lint-ignore"
+
+// ignoredBy reports whether the node at path, or any object above it, ignores
+// ruleId via x-lint-ignore, along with the reason given for it. The path is
+// walked through the original document, following internal $refs on the
+// way, so an ignore applies to everything reached through the object that
+// carries it, including shared definitions it uses.
+func ignoredBy(document interface{}, path []string, ruleId string) (string, bool) {
+	current := document
+	followed := make(map[string]bool)
+	for i := 0; ; {
+		if obj, ok := current.(map[string]interface{}); ok {
+			if reason, ok := ignoreReason(obj[IgnoreExtension], ruleId); ok {
+				return reason, true
+			}
+		}
+
+		if i == len(path) {
+			return "", false
+		}
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			if next, ok := v[path[i]]; ok {
+				current = next
+				break
+			}
+			ref, ok := v["$ref"].(string)
+			if !ok || !strings.HasPrefix(ref, "#/") || followed[ref] {
+				return "", false
+			}
+			followed[ref] = true
+			current = resolveJSONPointer(ref[2:], document)
+			continue
+		case []interface{}:
+			index, err := strconv.Atoi(path[i])
+			if err != nil || index < 0 || index >= len(v) {
+				return "", false
+			}
+			current = v[index]
+		default:
+			return "", false
+		}
+		i++
+		clear(followed)
+	}
+}
+
+func ignoreReason(ignore interface{}, ruleId string) (string, bool) {
+	switch v := ignore.(type) {
+	case string:
+		return "", v == ruleId
+	case []interface{}:
+		for _, item := range v {
+			if id, ok := item.(string); ok && id == ruleId {
+				return "", true
+			}
+		}
+	case map[string]interface{}:
+		if reason, ok := v[ruleId]; ok {
+			reasonStr, _ := reason.(string)
+			return reasonStr, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// RuleExecutionError reports a rule that panicked or ran past its timeout.
+// Stack holds the goroutine stack captured when the panic was recovered.
+type RuleExecutionError struct {
+	RuleID  string
+	Panic   interface{}
+	Stack   []byte
+	Timeout time.Duration
+}
+
+func (e *RuleExecutionError) Error() string {
+	if e.Panic != nil {
+		return fmt.Sprintf("rule %s panicked: %v", e.RuleID, e.Panic)
+	}
+	return fmt.Sprintf("rule %s timed out after %s", e.RuleID, e.Timeout)
+}
+
+// ExecuteRuleIsolated is ExecuteRuleWithCache with a panic in the rule's
+// functions recovered and returned as a *RuleExecutionError, so that one
+// broken rule cannot take down the whole run. A timeout greater than zero
+// bounds how long the caller waits; a rule that overruns it is abandoned and
+// left to finish in the background, since Go cannot stop it.
+func ExecuteRuleIsolated(rule *types.Rule, context types.RuleFunctionContext, cache *QueryCache, timeout time.Duration) ([]types.RuleFunctionResult, error) {
+	type outcome struct {
+		results []types.RuleFunctionResult
+		err     error
+	}
+
+	// Buffered so an abandoned rule can still deliver its outcome and exit.
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: &RuleExecutionError{
+					RuleID: context.RuleID,
+					Panic:  r,
+					Stack:  debug.Stack(),
+				}}
+			}
+		}()
+
+		results, err := ExecuteRuleWithCache(rule, context, cache)
+		done <- outcome{results: results, err: err}
+	}()
+
+	if timeout <= 0 {
+		o := <-done
+		return o.results, o.err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case o := <-done:
+		return o.results, o.err
+	case <-timer.C:
+		return nil, &RuleExecutionError{RuleID: context.RuleID, Timeout: timeout}
+	}
+}
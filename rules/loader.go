@@ -0,0 +1,164 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// aliasPattern matches an alias reference at the start of a `given` path,
+// e.g. `#MethodParams` in `#MethodParams.schema`.
+var aliasPattern = regexp.MustCompile(`^#([A-Za-z0-9_-]+)`)
+
+// LoadRuleset reads a rules file, merges in every ruleset it extends and
+// expands alias references in each rule's `given` paths.
+func LoadRuleset(path string) (*types.Ruleset, error) {
+	ruleset, err := loadRulesetFile(path, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	for ruleId, rule := range ruleset.Rules {
+		given, err := ExpandAliases(rule.Given, ruleset.Aliases)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", ruleId, err)
+		}
+		rule.Given = given
+
+		if rule.Severity == "" {
+			rule.Severity = types.SeverityError
+		}
+		if !types.ValidSeverity(rule.Severity) {
+			return nil, fmt.Errorf("rule %s: invalid severity: %s", ruleId, rule.Severity)
+		}
+		ruleset.Rules[ruleId] = rule
+	}
+
+	for _, override := range ruleset.Overrides {
+		if len(override.Files) == 0 {
+			return nil, fmt.Errorf("override must have files")
+		}
+		for ruleId, severity := range override.Rules {
+			if !types.ValidSeverity(severity) {
+				return nil, fmt.Errorf("override for rule %s: invalid severity: %s", ruleId, severity)
+			}
+		}
+	}
+
+	return ruleset, nil
+}
+
+// loadRulesetFile reads a single rules file and merges it over the rulesets
+// it extends. Rules and aliases defined in the file take precedence over
+// inherited ones.
+func loadRulesetFile(path string, loading map[string]bool) (*types.Ruleset, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if loading[absPath] {
+		return nil, fmt.Errorf("circular extends: %s", path)
+	}
+	loading[absPath] = true
+	defer delete(loading, absPath)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rules file: %w", err)
+	}
+
+	var ruleset types.Ruleset
+	if err := yaml.Unmarshal(data, &ruleset); err != nil {
+		return nil, fmt.Errorf("error parsing rules file %s: %w", path, err)
+	}
+
+	// Reporters configure the run rather than the rules, so they are not
+	// inherited from extended rulesets.
+	merged := &types.Ruleset{
+		Description: ruleset.Description,
+		Extends:     ruleset.Extends,
+		Aliases:     make(map[string]types.StringList),
+		Rules:       make(map[string]types.Rule),
+		Reporters:   ruleset.Reporters,
+	}
+
+	for _, parentPath := range ruleset.Extends {
+		if !filepath.IsAbs(parentPath) {
+			parentPath = filepath.Join(filepath.Dir(path), parentPath)
+		}
+
+		parent, err := loadRulesetFile(parentPath, loading)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, alias := range parent.Aliases {
+			merged.Aliases[name] = alias
+		}
+		for ruleId, rule := range parent.Rules {
+			merged.Rules[ruleId] = rule
+		}
+		merged.Overrides = append(merged.Overrides, parent.Overrides...)
+	}
+
+	for name, alias := range ruleset.Aliases {
+		merged.Aliases[name] = alias
+	}
+	for ruleId, rule := range ruleset.Rules {
+		merged.Rules[ruleId] = rule
+	}
+	merged.Overrides = append(merged.Overrides, ruleset.Overrides...)
+
+	return merged, nil
+}
+
+// ExpandAliases replaces alias references in the given paths with the paths
+// they stand for. An alias may itself start with another alias, and an alias
+// with several paths expands to one path per entry.
+func ExpandAliases(given types.StringList, aliases map[string]types.StringList) (types.StringList, error) {
+	var expanded types.StringList
+	for _, path := range given {
+		paths, err := expandAlias(path, aliases, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, paths...)
+	}
+	return expanded, nil
+}
+
+func expandAlias(path string, aliases map[string]types.StringList, expanding map[string]bool) ([]string, error) {
+	match := aliasPattern.FindStringSubmatch(path)
+	if match == nil {
+		return []string{path}, nil
+	}
+
+	name := match[1]
+	alias, ok := aliases[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown alias: #%s", name)
+	}
+	if expanding[name] {
+		return nil, fmt.Errorf("circular alias: #%s", name)
+	}
+	expanding[name] = true
+	defer delete(expanding, name)
+
+	suffix := strings.TrimPrefix(path, match[0])
+
+	var paths []string
+	for _, target := range alias {
+		expanded, err := expandAlias(target+suffix, aliases, expanding)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, expanded...)
+	}
+	return paths, nil
+}
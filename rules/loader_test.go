@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func writeRulesFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+	return path
+}
+
+func TestLoadRulesetAliasesAndExtends(t *testing.T) {
+	dir := t.TempDir()
+
+	writeRulesFile(t, dir, "base.yml", `
+aliases:
+  Methods: "$.methods[*]"
+  ParamSchemas: "#Methods.params[*].schema"
+rules:
+  method-description:
+    given: "#Methods"
+    then:
+      field: "description"
+      function: "truthy"
+  param-schema-type:
+    given: "#ParamSchemas"
+    then:
+      field: "type"
+      function: "truthy"
+`)
+	path := writeRulesFile(t, dir, "rules.yml", `
+extends: "base.yml"
+aliases:
+  Documented:
+    - "#Methods"
+    - "$.components.errors[*]"
+rules:
+  method-description:
+    given: "#Documented"
+    then:
+      field: "description"
+      function: "truthy"
+`)
+
+	ruleset, err := LoadRuleset(path)
+	if err != nil {
+		t.Fatalf("LoadRuleset() returned error: %v", err)
+	}
+
+	if len(ruleset.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(ruleset.Rules))
+	}
+
+	expected := map[string]types.StringList{
+		"method-description": {"$.methods[*]", "$.components.errors[*]"},
+		"param-schema-type":  {"$.methods[*].params[*].schema"},
+	}
+	for ruleId, given := range expected {
+		if got := ruleset.Rules[ruleId].Given; !reflect.DeepEqual(got, given) {
+			t.Errorf("Rule %s: expected given %v, got %v", ruleId, given, got)
+		}
+	}
+}
+
+func TestLoadRulesetErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		expectedMsg string
+	}{
+		{
+			name: "unknown alias",
+			files: map[string]string{
+				"rules.yml": `
+rules:
+  method-description:
+    given: "#Methods"
+    then:
+      function: "truthy"
+`,
+			},
+			expectedMsg: "unknown alias: #Methods",
+		},
+		{
+			name: "circular alias",
+			files: map[string]string{
+				"rules.yml": `
+aliases:
+  A: "#B.a"
+  B: "#A.b"
+rules:
+  method-description:
+    given: "#A"
+    then:
+      function: "truthy"
+`,
+			},
+			expectedMsg: "circular alias",
+		},
+		{
+			name: "circular extends",
+			files: map[string]string{
+				"rules.yml": `extends: "other.yml"`,
+				"other.yml": `extends: "rules.yml"`,
+			},
+			expectedMsg: "circular extends",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeRulesFile(t, dir, name, content)
+			}
+
+			_, err := LoadRuleset(filepath.Join(dir, "rules.yml"))
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.expectedMsg)
+			}
+			if !strings.Contains(err.Error(), tt.expectedMsg) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectedMsg, err.Error())
+			}
+		})
+	}
+}
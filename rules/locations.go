@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// SourceMap maps the nodes of a JSON document to where they are in its
+// source text.
+type SourceMap struct {
+	data       []byte
+	lineStarts []int
+	ranges     map[string]types.Range
+}
+
+// NewSourceMap scans a JSON document and records the range of every node,
+// from the start of its key, if it has one, to the end of its value.
+func NewSourceMap(data []byte) (*SourceMap, error) {
+	m := &SourceMap{
+		data:       data,
+		lineStarts: []int{0},
+		ranges:     make(map[string]types.Range),
+	}
+	for i, b := range data {
+		if b == '\n' {
+			m.lineStarts = append(m.lineStarts, i+1)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := m.scan(dec, nil, -1); err != nil {
+		return nil, fmt.Errorf("error mapping document source: %w", err)
+	}
+	return m, nil
+}
+
+// scan reads the next value from dec and records its range and those of its
+// children. keyStart is the offset of the value's key, or -1 if it has none.
+func (m *SourceMap) scan(dec *json.Decoder, path []string, keyStart int) error {
+	start := m.nextToken(int(dec.InputOffset()))
+	if keyStart < 0 {
+		keyStart = start
+	}
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		for dec.More() {
+			keyOffset := m.nextToken(int(dec.InputOffset()))
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := m.scan(dec, appendPath(path, key.(string)), keyOffset); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := m.scan(dec, appendPath(path, strconv.Itoa(i)), -1); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+
+	m.ranges[types.JSONPath(path)] = types.Range{
+		Start: m.position(keyStart),
+		End:   m.position(int(dec.InputOffset())),
+	}
+	return nil
+}
+
+// nextToken returns the offset of the first token at or after offset,
+// skipping whitespace and the separators the decoder consumes implicitly.
+func (m *SourceMap) nextToken(offset int) int {
+	for offset < len(m.data) {
+		switch m.data[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+func (m *SourceMap) position(offset int) types.Position {
+	line := sort.Search(len(m.lineStarts), func(i int) bool {
+		return m.lineStarts[i] > offset
+	}) - 1
+	column := utf8.RuneCount(m.data[m.lineStarts[line]:offset]) + 1
+	return types.Position{Line: line + 1, Column: column}
+}
+
+// Range returns the range of the node at path. When the node does not
+// exist, e.g. a missing required field, the range of its nearest existing
+// ancestor is returned instead.
+func (m *SourceMap) Range(path []string) (types.Range, bool) {
+	for i := len(path); i >= 0; i-- {
+		if r, ok := m.ranges[types.JSONPath(path[:i])]; ok {
+			return r, true
+		}
+	}
+	return types.Range{}, false
+}
+
+// LocateResults sets File on every result and Range on those with a path.
+func LocateResults(results []types.RuleFunctionResult, file string, sourceMap *SourceMap) {
+	for i := range results {
+		results[i].File = file
+		if len(results[i].Path) == 0 {
+			continue
+		}
+		if r, ok := sourceMap.Range(results[i].Path); ok {
+			results[i].Range = &r
+		}
+	}
+}
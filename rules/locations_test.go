@@ -0,0 +1,45 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestSourceMap(t *testing.T) {
+	source := `{
+  "info": {"title": "Tést API", "version": "1.0.0"},
+  "methods": [
+    {"name": "foo", "params": []}
+  ]
+}
+`
+	sourceMap, err := NewSourceMap([]byte(source))
+	if err != nil {
+		t.Fatalf("NewSourceMap() returned error: %v", err)
+	}
+
+	tests := []struct {
+		path     []string
+		expected types.Range
+	}{
+		{nil, types.Range{Start: types.Position{Line: 1, Column: 1}, End: types.Position{Line: 6, Column: 2}}},
+		{[]string{"info"}, types.Range{Start: types.Position{Line: 2, Column: 3}, End: types.Position{Line: 2, Column: 52}}},
+		{[]string{"info", "version"}, types.Range{Start: types.Position{Line: 2, Column: 33}, End: types.Position{Line: 2, Column: 51}}},
+		{[]string{"methods", "0"}, types.Range{Start: types.Position{Line: 4, Column: 5}, End: types.Position{Line: 4, Column: 34}}},
+		{[]string{"methods", "0", "name"}, types.Range{Start: types.Position{Line: 4, Column: 6}, End: types.Position{Line: 4, Column: 19}}},
+		// Missing nodes fall back to their nearest existing ancestor
+		{[]string{"methods", "0", "description"}, types.Range{Start: types.Position{Line: 4, Column: 5}, End: types.Position{Line: 4, Column: 34}}},
+	}
+
+	for _, tt := range tests {
+		r, ok := sourceMap.Range(tt.path)
+		if !ok {
+			t.Errorf("Expected a range for %s", types.JSONPath(tt.path))
+			continue
+		}
+		if r != tt.expected {
+			t.Errorf("Expected range %v for %s, got %v", tt.expected, types.JSONPath(tt.path), r)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// placeholderPattern matches a message template placeholder such as
+// {{property}} or {{ value }}.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// renderMessage fills in the placeholders of a rule's message template.
+// Unknown placeholders are left as written.
+func renderMessage(template string, vars map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// messageVars returns the values available to a message template for a
+// single result produced by a rule function.
+func messageVars(rule *types.Rule, action *types.RuleAction, value interface{}, result types.RuleFunctionResult) map[string]string {
+	property := action.Field
+	if property == "" && len(result.Path) > 0 {
+		property = result.Path[len(result.Path)-1]
+	}
+
+	return map[string]string{
+		"property":    property,
+		"value":       formatValue(value),
+		"path":        types.JSONPath(result.Path),
+		"description": rule.Description,
+		"error":       result.Message,
+	}
+}
+
+func formatValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
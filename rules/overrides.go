@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// RulesForFile returns the ruleset's rules with the severities set by the
+// overrides that match file, applied in order. Overrides with paths only
+// matter here when they switch a rule back on after it was switched off, in
+// which case the rule takes their severity so that it still runs and
+// ApplyOverrides can decide which of its findings to keep. Rules switched
+// off keep their entry with severity off.
+func RulesForFile(ruleset *types.Ruleset, file string) map[string]types.Rule {
+	result := make(map[string]types.Rule, len(ruleset.Rules))
+	for ruleId, rule := range ruleset.Rules {
+		result[ruleId] = rule
+	}
+
+	for _, override := range ruleset.Overrides {
+		if !matchesFile(override.Files, file) {
+			continue
+		}
+		for ruleId, severity := range override.Rules {
+			rule, ok := result[ruleId]
+			if !ok {
+				continue
+			}
+			if len(override.Paths) > 0 && (rule.Severity != types.SeverityOff || severity == types.SeverityOff) {
+				continue
+			}
+			rule.Severity = severity
+			result[ruleId] = rule
+		}
+	}
+
+	return result
+}
+
+// ApplyOverrides gives each finding the severity of the last override that
+// applies to it: one matching file without paths, or one whose paths select
+// a subtree the finding is inside. A finding in a shared definition is
+// inside a subtree if every place the definition is used is. Findings whose
+// rule ends up switched off are dropped, and rule execution errors are left
+// as they are.
+func ApplyOverrides(ruleset *types.Ruleset, file string, document interface{}, results []types.RuleFunctionResult) ([]types.RuleFunctionResult, error) {
+	cache := NewQueryCache()
+
+	matching := make([]bool, len(ruleset.Overrides))
+	subtrees := make([][][]string, len(ruleset.Overrides))
+	for i, override := range ruleset.Overrides {
+		matching[i] = matchesFile(override.Files, file)
+		if !matching[i] {
+			continue
+		}
+		for _, path := range override.Paths {
+			nodes, err := cache.Query(path, document)
+			if err != nil {
+				return nil, fmt.Errorf("error getting override JSON path %s: %w", path, err)
+			}
+			for _, node := range nodes {
+				if node.Path != nil {
+					subtrees[i] = append(subtrees[i], node.Path)
+				}
+			}
+		}
+	}
+
+	var kept []types.RuleFunctionResult
+	for _, result := range results {
+		if result.IsError() {
+			kept = append(kept, result)
+			continue
+		}
+
+		// A rule switched off in the ruleset only runs because a path
+		// override switched it back on, so it stays off elsewhere.
+		severity, applied := "", false
+		if ruleset.Rules[result.RuleID].Severity == types.SeverityOff {
+			severity, applied = types.SeverityOff, true
+		}
+		for i, override := range ruleset.Overrides {
+			overrideSeverity, ok := override.Rules[result.RuleID]
+			if !ok || !matching[i] {
+				continue
+			}
+			if len(override.Paths) > 0 && !resultInSubtree(result, subtrees[i]) {
+				continue
+			}
+			severity, applied = overrideSeverity, true
+		}
+
+		if applied {
+			if severity == types.SeverityOff {
+				continue
+			}
+			result.Severity = severity
+		}
+		kept = append(kept, result)
+	}
+
+	return kept, nil
+}
+
+// resultInSubtree reports whether a finding is inside one of subtrees,
+// either at its own path or, for a finding collapsed to a shared
+// definition, at each of its usage sites.
+func resultInSubtree(result types.RuleFunctionResult, subtrees [][]string) bool {
+	if inSubtree(result.Path, subtrees) {
+		return true
+	}
+
+	sites := append(append([][]string{}, result.Related...), result.IgnoredAt...)
+	if len(sites) == 0 {
+		return false
+	}
+	for _, site := range sites {
+		if !inSubtree(site, subtrees) {
+			return false
+		}
+	}
+	return true
+}
+
+func inSubtree(path []string, subtrees [][]string) bool {
+	for _, subtree := range subtrees {
+		if len(subtree) > len(path) {
+			continue
+		}
+		matches := true
+		for i, segment := range subtree {
+			if path[i] != segment {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesFile(globs []string, file string) bool {
+	file = filepath.ToSlash(filepath.Clean(file))
+	for _, glob := range globs {
+		if globPattern(glob).MatchString(file) {
+			return true
+		}
+		// Patterns without a directory match the file name anywhere
+		if !strings.Contains(glob, "/") && globPattern(glob).MatchString(filepath.Base(file)) {
+			return true
+		}
+	}
+	return false
+}
+
+// globPattern converts a file glob to a regular expression. `**` matches
+// across directories, `*` and `?` match within a single path segment.
+func globPattern(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			sb.WriteString("[^/]*")
+		case glob[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
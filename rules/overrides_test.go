@@ -0,0 +1,194 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestRulesForFile(t *testing.T) {
+	ruleset := &types.Ruleset{
+		Rules: map[string]types.Rule{
+			"method-examples": {Severity: types.SeverityError},
+			"method-errors":   {Severity: types.SeverityError},
+		},
+		Overrides: []types.Override{
+			{
+				Files: types.StringList{"experimental/**/*.json"},
+				Rules: map[string]string{"method-examples": types.SeverityWarn},
+			},
+			{
+				Files: types.StringList{"legacy.json"},
+				Rules: map[string]string{"method-errors": types.SeverityOff},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		expected map[string]string
+	}{
+		{
+			name:     "no matching override",
+			file:     "stable/openrpc.json",
+			expected: map[string]string{"method-examples": types.SeverityError, "method-errors": types.SeverityError},
+		},
+		{
+			name:     "glob across directories",
+			file:     "experimental/a/b/openrpc.json",
+			expected: map[string]string{"method-examples": types.SeverityWarn, "method-errors": types.SeverityError},
+		},
+		{
+			name:     "file name anywhere",
+			file:     "specs/legacy.json",
+			expected: map[string]string{"method-examples": types.SeverityError, "method-errors": types.SeverityOff},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := RulesForFile(ruleset, tt.file)
+			for ruleId, severity := range tt.expected {
+				if rules[ruleId].Severity != severity {
+					t.Errorf("Rule %s: expected severity %q, got %q", ruleId, severity, rules[ruleId].Severity)
+				}
+			}
+		})
+	}
+
+	if ruleset.Rules["method-examples"].Severity != types.SeverityError {
+		t.Errorf("RulesForFile() must not modify the ruleset")
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	ruleset := &types.Ruleset{
+		Overrides: []types.Override{
+			{
+				Files: types.StringList{"*.json"},
+				Paths: types.StringList{`$.methods[?(@.name == "experimental")]`},
+				Rules: map[string]string{
+					"method-examples": types.SeverityWarn,
+					"method-errors":   types.SeverityOff,
+				},
+			},
+		},
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "stable"},
+			map[string]interface{}{"name": "experimental"},
+		},
+	}
+
+	results := []types.RuleFunctionResult{
+		{RuleID: "method-examples", Path: []string{"methods", "0", "examples"}, Severity: types.SeverityError},
+		{RuleID: "method-examples", Path: []string{"methods", "1", "examples"}, Severity: types.SeverityError},
+		{RuleID: "method-errors", Path: []string{"methods", "1", "errors"}, Severity: types.SeverityError},
+		// Shared definitions: only used in the subtree, and also used outside it
+		{
+			RuleID:    "method-examples",
+			Path:      []string{"components", "schemas", "Only", "title"},
+			Related:   [][]string{{"methods", "1", "result", "schema", "title"}},
+			IgnoredAt: [][]string{{"methods", "1", "params", "0", "schema", "title"}},
+			Severity:  types.SeverityError,
+		},
+		{
+			RuleID:   "method-examples",
+			Path:     []string{"components", "schemas", "Both", "title"},
+			Related:  [][]string{{"methods", "0", "result", "schema", "title"}, {"methods", "1", "result", "schema", "title"}},
+			Severity: types.SeverityError,
+		},
+	}
+
+	results, err := ApplyOverrides(ruleset, "openrpc.json", document, results)
+	if err != nil {
+		t.Fatalf("ApplyOverrides() returned error: %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Severity != types.SeverityError {
+		t.Errorf("Expected finding outside the subtree to keep its severity, got %q", results[0].Severity)
+	}
+	if results[1].Severity != types.SeverityWarn {
+		t.Errorf("Expected finding inside the subtree to be a warning, got %q", results[1].Severity)
+	}
+	if results[2].Severity != types.SeverityWarn {
+		t.Errorf("Expected shared finding only used inside the subtree to be a warning, got %q", results[2].Severity)
+	}
+	if results[3].Severity != types.SeverityError {
+		t.Errorf("Expected shared finding also used outside the subtree to keep its severity, got %q", results[3].Severity)
+	}
+}
+
+func TestApplyOverridesOrder(t *testing.T) {
+	ruleset := &types.Ruleset{
+		Rules: map[string]types.Rule{
+			"method-examples": {Severity: types.SeverityError},
+			"method-errors":   {Severity: types.SeverityError},
+		},
+		Overrides: []types.Override{
+			{
+				Files: types.StringList{"*.json"},
+				Paths: types.StringList{`$.methods[?(@.name == "experimental")]`},
+				Rules: map[string]string{"method-examples": types.SeverityError},
+			},
+			{
+				Files: types.StringList{"*.json"},
+				Rules: map[string]string{
+					"method-examples": types.SeverityWarn,
+					"method-errors":   types.SeverityOff,
+				},
+			},
+			{
+				Files: types.StringList{"*.json"},
+				Paths: types.StringList{`$.methods[?(@.name == "experimental")]`},
+				Rules: map[string]string{"method-errors": types.SeverityWarn},
+			},
+		},
+	}
+
+	// A later path override switches a rule back on, so it must still run
+	rules := RulesForFile(ruleset, "openrpc.json")
+	if rules["method-examples"].Severity != types.SeverityWarn {
+		t.Errorf("Expected the later file override to set method-examples to warn, got %q", rules["method-examples"].Severity)
+	}
+	if rules["method-errors"].Severity != types.SeverityWarn {
+		t.Errorf("Expected method-errors to run for the later path override, got %q", rules["method-errors"].Severity)
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "stable"},
+			map[string]interface{}{"name": "experimental"},
+		},
+	}
+	results := []types.RuleFunctionResult{
+		{RuleID: "method-examples", Path: []string{"methods", "1", "examples"}, Severity: types.SeverityWarn},
+		{RuleID: "method-errors", Path: []string{"methods", "0", "errors"}, Severity: types.SeverityWarn},
+		{RuleID: "method-errors", Path: []string{"methods", "1", "errors"}, Severity: types.SeverityWarn},
+		{RuleID: "method-errors", Severity: types.SeverityError, Kind: types.KindError, Message: "unknown function: nope"},
+	}
+
+	results, err := ApplyOverrides(ruleset, "openrpc.json", document, results)
+	if err != nil {
+		t.Fatalf("ApplyOverrides() returned error: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Severity != types.SeverityWarn {
+		t.Errorf("Expected the later file override to win over the earlier path override, got %q", results[0].Severity)
+	}
+	if types.JSONPath(results[1].Path) != "$.methods[1].errors" || results[1].Severity != types.SeverityWarn {
+		t.Errorf("Expected method-errors only inside the later path override, got %+v", results[1])
+	}
+	if !results[2].IsError() || results[2].Severity != types.SeverityError {
+		t.Errorf("Expected the rule execution error to be left as it is, got %+v", results[2])
+	}
+}
@@ -0,0 +1,267 @@
+package rules
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// nodeKey identifies an object or array node in a decoded document. Scalars
+// have no identity of their own and cannot be keyed, and neither can empty
+// arrays since they may all share the same backing pointer.
+type nodeKey struct {
+	kind    reflect.Kind
+	pointer uintptr
+	length  int
+}
+
+func keyForNode(node interface{}) (nodeKey, bool) {
+	switch n := node.(type) {
+	case []interface{}:
+		if len(n) == 0 {
+			return nodeKey{}, false
+		}
+	case map[string]interface{}:
+	default:
+		return nodeKey{}, false
+	}
+
+	v := reflect.ValueOf(node)
+	return nodeKey{kind: v.Kind(), pointer: v.Pointer(), length: v.Len()}, true
+}
+
+// appendPath returns a new path with segment appended, leaving path intact.
+func appendPath(path []string, segment string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, segment)
+}
+
+// definitionPath maps a path through one or more $ref usage sites back to
+// the path of the definition it ends up in, using the longest matching usage
+// site in origins at each step. Paths outside any $ref are returned as-is.
+func definitionPath(path []string, origins map[string]types.RefOrigin) []string {
+	for step := 0; step <= len(origins); step++ {
+		mapped := false
+		for n := len(path); n >= 0; n-- {
+			if origin, ok := origins[types.JSONPath(path[:n])]; ok {
+				result := make([]string, 0, len(origin.Definition)+len(path)-n)
+				result = append(result, origin.Definition...)
+				path = append(result, path[n:]...)
+				mapped = true
+				break
+			}
+		}
+		if !mapped {
+			break
+		}
+	}
+	return path
+}
+
+// usageSites lists where the definition containing path is used, i.e. the
+// $ref usage sites of the longest definition that path falls under, each
+// extended with the rest of path. A usage site inside another shared
+// definition is replaced by the usage sites of that definition, in turn, so
+// every site is where the finding is reached from outside any definition.
+func usageSites(path []string, usages map[string][][]string) [][]string {
+	related := expandUsageSites(path, usages, make(map[string]bool))
+
+	seen := make(map[string]bool, len(related))
+	unique := related[:0]
+	for _, site := range related {
+		if key := types.JSONPath(site); !seen[key] {
+			seen[key] = true
+			unique = append(unique, site)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return comparePaths(unique[i], unique[j]) < 0 })
+	return unique
+}
+
+// expandUsageSites does the work of usageSites. Definitions being expanded
+// are tracked in visiting so that $ref cycles end.
+func expandUsageSites(path []string, usages map[string][][]string, visiting map[string]bool) [][]string {
+	for n := len(path); n >= 0; n-- {
+		key := types.JSONPath(path[:n])
+		sites, ok := usages[key]
+		if !ok {
+			continue
+		}
+		if visiting[key] {
+			return nil
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+
+		var related [][]string
+		for _, site := range sites {
+			usage := make([]string, 0, len(site)+len(path)-n)
+			usage = append(usage, site...)
+			usage = append(usage, path[n:]...)
+			if outer := expandUsageSites(usage, usages, visiting); len(outer) > 0 {
+				related = append(related, outer...)
+			} else {
+				related = append(related, usage)
+			}
+		}
+		return related
+	}
+	return nil
+}
+
+// definitionMessage rewrites paths into usage that a message names, e.g.
+// through truthy or a {{path}} placeholder, as paths into the definition,
+// so a collapsed finding reads the same whichever usage it was found at.
+func definitionMessage(message string, usage []string, origins map[string]types.RefOrigin) string {
+	// Longest first, so a nested definition wins over the one containing it
+	for n := len(usage); n > 0; n-- {
+		from := types.JSONPath(usage[:n])
+		if to := types.JSONPath(definitionPath(usage[:n], origins)); to != from {
+			message = replacePath(message, from, to)
+		}
+	}
+	return message
+}
+
+// replacePath replaces each occurrence of the JSONPath from in s with to,
+// skipping occurrences that are only the start of a longer key, such as
+// $.a in $.ab.
+func replacePath(s, from, to string) string {
+	var sb strings.Builder
+	for {
+		i := strings.Index(s, from)
+		if i < 0 {
+			break
+		}
+		end := i + len(from)
+		sb.WriteString(s[:i])
+		if end < len(s) && isPathKeyByte(s[end]) {
+			sb.WriteString(from)
+		} else {
+			sb.WriteString(to)
+		}
+		s = s[end:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
+
+func isPathKeyByte(b byte) bool {
+	return b == '_' || b == '$' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// collapseRefResults reports findings inside shared $ref targets, which are
+// checked at every usage site, once at the definition site, with every usage
+// site listed as a related location. The collapsed finding is suppressed
+// only if it is ignored at every usage site; otherwise the sites that
+// ignore it are listed in IgnoredAt instead of Related.
+func collapseRefResults(results []types.RuleFunctionResult, origins map[string]types.RefOrigin) []types.RuleFunctionResult {
+	if len(origins) == 0 {
+		return results
+	}
+
+	usages := make(map[string][][]string)
+	for _, origin := range origins {
+		key := types.JSONPath(origin.Definition)
+		usages[key] = append(usages[key], origin.Usage)
+	}
+	var collapsed []types.RuleFunctionResult
+	groups := make(map[string]int)
+	ordinals := make(map[string]int)
+	var ignored []map[string][]string // Usage paths ignoring each collapsed finding
+
+	for _, result := range results {
+		if len(result.Path) == 0 {
+			collapsed = append(collapsed, result)
+			ignored = append(ignored, nil)
+			continue
+		}
+
+		// Each usage site checks the same definition, but messages may name
+		// the usage, so the nth result at every usage site is the same
+		// finding.
+		usage := result.Path
+		ordinal := ordinals[types.JSONPath(usage)]
+		ordinals[types.JSONPath(usage)]++
+
+		result.Path = definitionPath(usage, origins)
+		result.Message = definitionMessage(result.Message, usage, origins)
+		key := types.JSONPath(result.Path) + "\x00" + strconv.Itoa(ordinal)
+
+		i, ok := groups[key]
+		if !ok {
+			i = len(collapsed)
+			groups[key] = i
+			result.Related = append(result.Related, usageSites(result.Path, usages)...)
+			collapsed = append(collapsed, result)
+			ignored = append(ignored, make(map[string][]string))
+		} else if collapsed[i].Suppressed && !result.Suppressed {
+			// Report the finding as found at a usage that does not ignore it
+			result.Related = collapsed[i].Related
+			collapsed[i] = result
+		}
+		if result.Suppressed {
+			ignored[i][types.JSONPath(usage)] = usage
+		}
+	}
+
+	for i := range collapsed {
+		if collapsed[i].Suppressed || len(ignored[i]) == 0 {
+			continue
+		}
+
+		var related [][]string
+		for _, site := range collapsed[i].Related {
+			if _, ok := ignored[i][types.JSONPath(site)]; !ok {
+				related = append(related, site)
+			}
+		}
+		collapsed[i].Related = related
+
+		for _, site := range ignored[i] {
+			collapsed[i].IgnoredAt = append(collapsed[i].IgnoredAt, site)
+		}
+		sort.Slice(collapsed[i].IgnoredAt, func(a, b int) bool {
+			return comparePaths(collapsed[i].IgnoredAt[a], collapsed[i].IgnoredAt[b]) < 0
+		})
+	}
+
+	return collapsed
+}
+
+// SortResults orders results by rule ID and then by path, comparing array
+// indices numerically, so output does not depend on map iteration or the
+// order rules finished in. Results with equal keys keep their order.
+func SortResults(results []types.RuleFunctionResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].RuleID != results[j].RuleID {
+			return results[i].RuleID < results[j].RuleID
+		}
+		return comparePaths(results[i].Path, results[j].Path) < 0
+	})
+}
+
+func comparePaths(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		ai, aErr := strconv.Atoi(a[i])
+		bi, bErr := strconv.Atoi(b[i])
+		if aErr == nil && bErr == nil {
+			if ai < bi {
+				return -1
+			}
+			return 1
+		}
+		if a[i] < b[i] {
+			return -1
+		}
+		return 1
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,381 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/scanner"
+	"unicode"
+
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
+)
+
+// QueryCache compiles each JSONPath expression once and evaluates it once
+// per document, so rules sharing a `given` path share its matches. A
+// QueryCache is safe for concurrent use and assumes the documents it sees
+// are not modified.
+type QueryCache struct {
+	mu        sync.Mutex
+	compiled  map[string]*compiledQuery
+	documents map[nodeKey]*documentQueries
+}
+
+// compiledQuery is a JSONPath expression ready to evaluate. When the
+// expression is a plain path its segments are kept, and trace evaluates it
+// to its matches keyed by the values its wildcards took, from which the path
+// of each match is rebuilt.
+type compiledQuery struct {
+	once     sync.Once
+	eval     gval.Evaluable
+	trace    gval.Evaluable
+	segments []querySegment
+	err      error
+}
+
+type documentQueries struct {
+	mu      sync.Mutex
+	results map[string]*queryResult
+}
+
+type queryResult struct {
+	once  sync.Once
+	nodes []matchedNode
+	err   error
+}
+
+func NewQueryCache() *QueryCache {
+	return &QueryCache{
+		compiled:  make(map[string]*compiledQuery),
+		documents: make(map[nodeKey]*documentQueries),
+	}
+}
+
+// Query returns the nodes matched by a JSONPath expression in document,
+// along with their paths in document order, unless the expression selects
+// something other than a path through the document. The returned nodes are
+// shared and must not be modified.
+func (c *QueryCache) Query(expr string, document interface{}) ([]matchedNode, error) {
+	query, err := c.compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := c.document(document)
+	if doc == nil {
+		return query.evaluate(document)
+	}
+
+	doc.mu.Lock()
+	result, ok := doc.results[expr]
+	if !ok {
+		result = &queryResult{}
+		doc.results[expr] = result
+	}
+	doc.mu.Unlock()
+
+	result.once.Do(func() {
+		defer recoverQuery(expr, &result.err)
+		result.nodes, result.err = query.evaluate(document)
+	})
+
+	return result.nodes, result.err
+}
+
+func (c *QueryCache) compile(expr string) (*compiledQuery, error) {
+	c.mu.Lock()
+	query, ok := c.compiled[expr]
+	if !ok {
+		query = &compiledQuery{}
+		c.compiled[expr] = query
+	}
+	c.mu.Unlock()
+
+	query.once.Do(func() {
+		defer recoverQuery(expr, &query.err)
+		query.eval, query.err = jsonpath.New(expr)
+		if query.err != nil {
+			return
+		}
+
+		segments, ok := parseQuerySegments(expr)
+		if !ok {
+			return
+		}
+		wildcards := 0
+		for _, segment := range segments {
+			if segment.Wildcard {
+				wildcards++
+			}
+		}
+		if wildcards > 0 {
+			trace, err := traceLanguage.NewEvaluable(traceExpression(expr, wildcards))
+			if err != nil {
+				return
+			}
+			query.trace = trace
+		}
+		query.segments = segments
+	})
+	if query.err != nil {
+		return nil, query.err
+	}
+	return query, nil
+}
+
+// recoverQuery turns a panic while compiling or evaluating expr into an
+// error stored in err. Compiled queries and their results are shared through
+// a sync.Once, so a panic left to the rule that hit it would leave every
+// other rule sharing the query with no matches and no error.
+func recoverQuery(expr string, err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("JSONPath %s panicked: %v", expr, r)
+	}
+}
+
+// document returns the cached queries for document, or nil if the document
+// has no identity to cache against.
+func (c *QueryCache) document(document interface{}) *documentQueries {
+	key, ok := keyForNode(document)
+	if !ok {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	doc, ok := c.documents[key]
+	if !ok {
+		doc = &documentQueries{results: make(map[string]*queryResult)}
+		c.documents[key] = doc
+	}
+	return doc
+}
+
+// evaluate returns the nodes matched in document. Matches of a traced
+// expression are put in document order, since wildcards over objects return
+// them in no particular order.
+func (q *compiledQuery) evaluate(document interface{}) ([]matchedNode, error) {
+	if q.segments == nil {
+		res, err := q.eval(context.Background(), document)
+		if err != nil {
+			return nil, err
+		}
+		return candidateNodes(res), nil
+	}
+
+	if q.trace == nil {
+		res, err := q.eval(context.Background(), document)
+		if err != nil {
+			return nil, err
+		}
+		return []matchedNode{{Value: res, Path: q.path(nil)}}, nil
+	}
+
+	res, err := q.trace(context.Background(), document)
+	if err != nil {
+		return nil, err
+	}
+	matches, _ := res.(map[string]interface{})
+
+	nodes := make([]matchedNode, 0, len(matches))
+	for key, value := range matches {
+		var wildcards []interface{}
+		if err := json.Unmarshal([]byte(key), &wildcards); err != nil {
+			return nil, fmt.Errorf("tracing JSONPath matches: %w", err)
+		}
+		nodes = append(nodes, matchedNode{Value: value, Path: q.path(wildcards)})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return comparePaths(nodes[i].Path, nodes[j].Path) < 0
+	})
+
+	for i := range nodes {
+		index := i
+		nodes[i].Index = &index
+	}
+	return nodes, nil
+}
+
+// path rebuilds the path of a match from the values the expression's
+// wildcards took for it.
+func (q *compiledQuery) path(wildcards []interface{}) []string {
+	path := []string{}
+	for _, segment := range q.segments {
+		if !segment.Wildcard {
+			path = append(path, segment.Key)
+			continue
+		}
+		if len(wildcards) == 0 {
+			break
+		}
+		switch keys := wildcards[0].(type) {
+		case string:
+			path = append(path, keys)
+		case []interface{}:
+			for _, key := range keys {
+				path = append(path, fmt.Sprint(key))
+			}
+		}
+		wildcards = wildcards[1:]
+	}
+	return path
+}
+
+// candidateNodes flattens the result of a JSONPath query that cannot be
+// traced into the list of matched nodes, none of which have a path.
+func candidateNodes(res interface{}) []matchedNode {
+	resArray, indexed := res.([]interface{})
+	if !indexed {
+		return []matchedNode{{Value: res}}
+	}
+
+	nodes := make([]matchedNode, len(resArray))
+	for i, item := range resArray {
+		index := i
+		nodes[i] = matchedNode{Value: item, Index: &index}
+	}
+	return nodes
+}
+
+// traceLanguage is JSONPath with placeholders for the wildcard values of
+// each match, plus a tracePath function that encodes them as a map key.
+var traceLanguage = gval.NewLanguage(
+	jsonpath.PlaceholderExtension(),
+	gval.Function("tracePath", func(wildcards ...interface{}) (interface{}, error) {
+		key, err := json.Marshal(wildcards)
+		return string(key), err
+	}),
+)
+
+// traceExpression wraps expr in a JSON object that maps the values of its
+// wildcards to each match, e.g. {tracePath(#0): $.methods[*]}.
+func traceExpression(expr string, wildcards int) string {
+	placeholders := make([]string, wildcards)
+	for i := range placeholders {
+		placeholders[i] = "#" + strconv.Itoa(i)
+	}
+	return "{tracePath(" + strings.Join(placeholders, ", ") + "): " + expr + "}"
+}
+
+// querySegment is one step of a JSONPath expression. A Wildcard step
+// selects any number of children, or with Recursive any number of
+// descendants, and its keys are only known once it has matched.
+type querySegment struct {
+	Key       string
+	Wildcard  bool
+	Recursive bool
+}
+
+// parseQuerySegments splits a JSONPath expression into its steps, the same
+// way jsonpath parses it. It reports false for expressions it cannot trace,
+// such as scripts, keys computed by expressions, or a path that is only
+// part of a larger expression.
+func parseQuerySegments(expr string) ([]querySegment, bool) {
+	var sc scanner.Scanner
+	sc.Init(strings.NewReader(expr))
+	sc.Error = func(*scanner.Scanner, string) {}
+	sc.IsIdentRune = func(r rune, pos int) bool {
+		return unicode.IsLetter(r) || r == '_' || (pos > 0 && unicode.IsDigit(r))
+	}
+
+	if sc.Scan() != '$' {
+		return nil, false
+	}
+
+	segments := []querySegment{}
+	for {
+		switch sc.Scan() {
+		case scanner.EOF:
+			return segments, true
+		case '.':
+			switch sc.Scan() {
+			case scanner.Ident:
+				segments = append(segments, querySegment{Key: sc.TokenText()})
+			case '*':
+				segments = append(segments, querySegment{Wildcard: true})
+			case '.':
+				segments = append(segments, querySegment{Wildcard: true, Recursive: true})
+				switch sc.Scan() {
+				case scanner.Ident:
+					segments = append(segments, querySegment{Key: sc.TokenText()})
+				case '*':
+					segments = append(segments, querySegment{Wildcard: true})
+				case '[':
+					// A bracket after .. always selects ambiguously
+					if _, _, ok := scanBracket(&sc); !ok {
+						return nil, false
+					}
+					segments = append(segments, querySegment{Wildcard: true})
+				default:
+					return nil, false
+				}
+			default:
+				return nil, false
+			}
+		case '[':
+			key, static, ok := scanBracket(&sc)
+			switch {
+			case !ok:
+				return nil, false
+			case static:
+				segments = append(segments, querySegment{Key: key})
+			default:
+				segments = append(segments, querySegment{Wildcard: true})
+			}
+		default:
+			return nil, false
+		}
+	}
+}
+
+// scanBracket scans the rest of a [...] step. It returns the key if the step
+// selects a single key given as a literal, and static false if it is a
+// wildcard, filter, range or list of keys. It reports false if the step
+// selects a single key computed by an expression.
+func scanBracket(sc *scanner.Scanner) (key string, static bool, ok bool) {
+	var tokens []rune
+	var texts []string
+	wildcard := false
+	depth := 0
+	for {
+		tok := sc.Scan()
+		switch tok {
+		case scanner.EOF:
+			return "", false, false
+		case '(', '[', '{':
+			depth++
+		case ')', '}':
+			depth--
+		case ']':
+			if depth == 0 {
+				if wildcard {
+					return "", false, true
+				}
+				if len(tokens) != 1 {
+					return "", false, false
+				}
+				switch tokens[0] {
+				case scanner.Int:
+					n, err := strconv.Atoi(texts[0])
+					return strconv.Itoa(n), err == nil, err == nil
+				case scanner.String, scanner.RawString:
+					s, err := strconv.Unquote(texts[0])
+					return s, err == nil, err == nil
+				}
+				return "", false, false
+			}
+			depth--
+		case '*', '?', ':', ',':
+			if depth == 0 && (len(tokens) == 0 || tok == ':' || tok == ',') {
+				wildcard = true
+			}
+		}
+		tokens = append(tokens, tok)
+		texts = append(texts, sc.TokenText())
+	}
+}
@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+func TestQueryCache(t *testing.T) {
+	document := map[string]interface{}{
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"C": map[string]interface{}{},
+				"A": map[string]interface{}{},
+				"B": map[string]interface{}{},
+			},
+		},
+	}
+
+	cache := NewQueryCache()
+
+	var wg sync.WaitGroup
+	results := make([][]matchedNode, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			nodes, err := cache.Query("$.components.schemas[*]", document)
+			if err != nil {
+				t.Errorf("Query() returned error: %v", err)
+			}
+			results[i] = nodes
+		}(i)
+	}
+	wg.Wait()
+
+	for _, nodes := range results[1:] {
+		if len(nodes) != 3 || &nodes[0] != &results[0][0] {
+			t.Fatalf("Expected every query to share the cached result")
+		}
+	}
+
+	for i, name := range []string{"A", "B", "C"} {
+		path := results[0][i].Path
+		if types.JSONPath(path) != "$.components.schemas."+name {
+			t.Errorf("Expected match %d at schema %s, got %s", i, name, types.JSONPath(path))
+		}
+	}
+
+	if _, err := cache.Query("$.nonexistent", document); err == nil {
+		t.Errorf("Expected error for unknown key, got nil")
+	}
+}
+
+func TestQueryPaths(t *testing.T) {
+	document := map[string]interface{}{
+		"info": map[string]interface{}{"title": "Test API"},
+		"methods": []interface{}{
+			map[string]interface{}{"name": "foo", "params": []interface{}{map[string]interface{}{"name": "a"}}},
+			map[string]interface{}{"name": "bar", "deprecated": true},
+		},
+	}
+
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{"$.info.title", []string{"$.info.title"}},
+		{`$["info"]`, []string{"$.info"}},
+		{"$.methods[*].name", []string{"$.methods[0].name", "$.methods[1].name"}},
+		{"$.methods[1].name", []string{"$.methods[1].name"}},
+		{"$.methods[0:1]", []string{"$.methods[0]"}},
+		{"$.methods[?(@.deprecated == true)].name", []string{"$.methods[1].name"}},
+		{"$..name", []string{"$.methods[0].name", "$.methods[0].params[0].name", "$.methods[1].name"}},
+		{"$.info.*", []string{"$.info.title"}},
+	}
+
+	cache := NewQueryCache()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			nodes, err := cache.Query(tt.expr, document)
+			if err != nil {
+				t.Fatalf("Query() returned error: %v", err)
+			}
+
+			var paths []string
+			for _, node := range nodes {
+				if node.Path == nil {
+					t.Fatalf("Expected match %v to have a path", node.Value)
+				}
+				paths = append(paths, types.JSONPath(node.Path))
+			}
+			if !reflect.DeepEqual(paths, tt.expected) {
+				t.Errorf("Expected paths %v, got %v", tt.expected, paths)
+			}
+		})
+	}
+}
+
+// Benchmark many rules sharing the same given path on a large document
+func BenchmarkExecuteRuleSharedGiven(b *testing.B) {
+	methods := make([]interface{}, 1000)
+	for i := range methods {
+		methods[i] = map[string]interface{}{
+			"name":        fmt.Sprintf("method_%d", i),
+			"description": "A method",
+			"params":      []interface{}{map[string]interface{}{"name": "param", "schema": map[string]interface{}{"type": "string"}}},
+		}
+	}
+	document := map[string]interface{}{"methods": methods}
+
+	rules := make([]*types.Rule, 50)
+	for i := range rules {
+		rules[i] = &types.Rule{
+			Given: types.StringList{"$.methods[*]"},
+			Then:  types.RuleActions{{Field: "description", Function: "truthy"}},
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache := NewQueryCache()
+		for _, rule := range rules {
+			ExecuteRuleWithCache(rule, types.RuleFunctionContext{Rule: rule, RuleID: "benchmark-rule", Document: document}, cache)
+		}
+	}
+}
+
+func TestQueryCachePanic(t *testing.T) {
+	cache := NewQueryCache()
+	query, err := cache.compile("$.info")
+	if err != nil {
+		t.Fatalf("compile() returned error: %v", err)
+	}
+	query.eval = func(context.Context, interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	// Every rule sharing the query gets the panic as an error, not just the
+	// first one to evaluate it.
+	document := map[string]interface{}{"info": map[string]interface{}{}}
+	for i := 0; i < 2; i++ {
+		nodes, err := cache.Query("$.info", document)
+		if err == nil || err.Error() != "JSONPath $.info panicked: boom" || nodes != nil {
+			t.Errorf("Query %d: expected the panic as an error, got %v, %v", i, nodes, err)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shanejonas/openrpc-linter/types"
+)
+
+// ResolveRefs resolves all internal $ref references in the document without
+// copying it. Subtrees that contain no $refs are shared with the original
+// document, and each $ref target is resolved once and shared by every place
+// that uses it. Alongside the resolved document it returns where each $ref
+// came from, keyed by the JSONPath of the usage site. A $ref that points
+// back into the target it is part of is left unresolved.
+func ResolveRefs(document interface{}) (interface{}, map[string]types.RefOrigin, error) {
+	r := &refResolver{
+		root:      document,
+		resolved:  make(map[nodeKey]interface{}),
+		resolving: make(map[nodeKey]bool),
+		origins:   make(map[string]types.RefOrigin),
+	}
+	return r.resolve(document, []string{}), r.origins, nil
+}
+
+type refResolver struct {
+	root      interface{}
+	resolved  map[nodeKey]interface{} // original node -> resolved node
+	resolving map[nodeKey]bool        // nodes being resolved, to detect cycles
+	origins   map[string]types.RefOrigin
+}
+
+// resolve returns the resolved form of a node at path in the original
+// document, reusing the node itself when nothing below it changes.
+func (r *refResolver) resolve(node interface{}, path []string) interface{} {
+	key, ok := keyForNode(node)
+	if !ok {
+		return node
+	}
+	if resolved, ok := r.resolved[key]; ok {
+		return resolved
+	}
+
+	r.resolving[key] = true
+	defer delete(r.resolving, key)
+
+	var result interface{}
+	switch v := node.(type) {
+	case map[string]interface{}:
+		result = r.resolveMap(v, path)
+	case []interface{}:
+		result = r.resolveArray(v, path)
+	}
+
+	r.resolved[key] = result
+	return result
+}
+
+func (r *refResolver) resolveMap(v map[string]interface{}, path []string) interface{} {
+	// Check if this is a $ref
+	if refStr, ok := v["$ref"].(string); ok {
+		// Handle internal refs (starting with #)
+		if strings.HasPrefix(refStr, "#/") {
+			target := resolveJSONPointer(refStr[2:], r.root) // Remove the "#/" prefix
+			targetKey, keyed := keyForNode(target)
+			if target != nil && !(keyed && r.resolving[targetKey]) {
+				pointer := jsonPointerSegments(refStr[2:])
+				r.origins[types.JSONPath(path)] = types.RefOrigin{Usage: path, Definition: pointer}
+				return r.resolve(target, pointer)
+			}
+		}
+		// If we can't resolve the ref, return the original $ref
+		return v
+	}
+
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var result map[string]interface{}
+	for _, k := range keys {
+		resolved := r.resolve(v[k], appendPath(path, k))
+		if result == nil && !sameNode(resolved, v[k]) {
+			// Copy on first change so unchanged maps stay shared
+			result = make(map[string]interface{}, len(v))
+			for key, value := range v {
+				result[key] = value
+			}
+		}
+		if result != nil {
+			result[k] = resolved
+		}
+	}
+
+	if result == nil {
+		return v
+	}
+	return result
+}
+
+func (r *refResolver) resolveArray(v []interface{}, path []string) interface{} {
+	var result []interface{}
+	for i, item := range v {
+		resolved := r.resolve(item, appendPath(path, strconv.Itoa(i)))
+		if result == nil && !sameNode(resolved, item) {
+			// Copy on first change so unchanged arrays stay shared
+			result = make([]interface{}, len(v))
+			copy(result, v)
+		}
+		if result != nil {
+			result[i] = resolved
+		}
+	}
+
+	if result == nil {
+		return v
+	}
+	return result
+}
+
+// sameNode reports whether resolving left a node unchanged. Scalars are
+// never changed by resolution.
+func sameNode(a, b interface{}) bool {
+	aKey, aOk := keyForNode(a)
+	bKey, bOk := keyForNode(b)
+	if aOk != bOk {
+		return false
+	}
+	return !aOk || aKey == bKey
+}
+
+// jsonPointerSegments splits a JSON pointer path into unescaped segments
+func jsonPointerSegments(path string) []string {
+	if path == "" {
+		return []string{}
+	}
+
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		part = strings.ReplaceAll(part, "~1", "/")
+		parts[i] = strings.ReplaceAll(part, "~0", "~")
+	}
+	return parts
+}
+
+// resolveJSONPointer resolves a JSON pointer path in the document
+func resolveJSONPointer(path string, document interface{}) interface{} {
+	if path == "" {
+		return document
+	}
+
+	current := document
+
+	for _, part := range jsonPointerSegments(path) {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			if val, exists := v[part]; exists {
+				current = val
+			} else {
+				return nil // Path not found
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil
+			}
+			current = v[index]
+		default:
+			return nil // Can't traverse further
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,108 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveRefs(t *testing.T) {
+	info := map[string]interface{}{"title": "Test API", "version": "1.0.0"}
+	document := map[string]interface{}{
+		"info": info,
+		"methods": []interface{}{
+			map[string]interface{}{"name": "foo", "params": []interface{}{
+				map[string]interface{}{"$ref": "#/components/contentDescriptors/Shared"},
+			}},
+			map[string]interface{}{"name": "bar", "params": []interface{}{
+				map[string]interface{}{"$ref": "#/components/contentDescriptors/Shared"},
+			}},
+		},
+		"components": map[string]interface{}{
+			"contentDescriptors": map[string]interface{}{
+				"Shared": map[string]interface{}{
+					"name":   "shared",
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/Node"},
+				},
+			},
+			"schemas": map[string]interface{}{
+				"Node": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"child": map[string]interface{}{"$ref": "#/components/schemas/Node"},
+					},
+				},
+			},
+		},
+	}
+
+	resolvedDoc, origins, err := ResolveRefs(document)
+	if err != nil {
+		t.Fatalf("ResolveRefs() returned error: %v", err)
+	}
+	resolved := resolvedDoc.(map[string]interface{})
+
+	// Subtrees without $refs are shared, not copied
+	if !sameNode(resolved["info"], info) {
+		t.Errorf("Expected info to be shared with the original document")
+	}
+
+	methods := resolved["methods"].([]interface{})
+	fooParam := methods[0].(map[string]interface{})["params"].([]interface{})[0].(map[string]interface{})
+	barParam := methods[1].(map[string]interface{})["params"].([]interface{})[0].(map[string]interface{})
+
+	if fooParam["name"] != "shared" {
+		t.Fatalf("Expected param to be resolved, got %v", fooParam)
+	}
+	if !sameNode(fooParam, barParam) {
+		t.Errorf("Expected both usages to share the resolved target")
+	}
+
+	schema := fooParam["schema"].(map[string]interface{})
+	if schema["type"] != "object" {
+		t.Errorf("Expected nested $ref to be resolved, got %v", schema)
+	}
+
+	// The self-reference is left as a $ref rather than looping forever
+	child := schema["properties"].(map[string]interface{})["child"].(map[string]interface{})
+	if child["$ref"] != "#/components/schemas/Node" {
+		t.Errorf("Expected recursive $ref to be left unresolved, got %v", child)
+	}
+
+	// The original document is untouched
+	originalParam := document["methods"].([]interface{})[0].(map[string]interface{})["params"].([]interface{})[0].(map[string]interface{})
+	if _, ok := originalParam["$ref"]; !ok {
+		t.Errorf("Expected the original document to keep its $refs")
+	}
+
+	origin, ok := origins["$.methods[1].params[0]"]
+	if !ok {
+		t.Fatalf("Expected origin for $.methods[1].params[0], got %v", origins)
+	}
+	if !reflect.DeepEqual(origin.Definition, []string{"components", "contentDescriptors", "Shared"}) {
+		t.Errorf("Expected definition path of Shared, got %v", origin.Definition)
+	}
+}
+
+func BenchmarkResolveRefs(b *testing.B) {
+	methods := make([]interface{}, 1000)
+	for i := range methods {
+		methods[i] = map[string]interface{}{
+			"name":   "method",
+			"params": []interface{}{map[string]interface{}{"$ref": "#/components/contentDescriptors/Shared"}},
+			"result": map[string]interface{}{"name": "result", "schema": map[string]interface{}{"type": "string"}},
+		}
+	}
+	document := map[string]interface{}{
+		"methods": methods,
+		"components": map[string]interface{}{
+			"contentDescriptors": map[string]interface{}{
+				"Shared": map[string]interface{}{"name": "shared", "schema": map[string]interface{}{"type": "string"}},
+			},
+		},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ResolveRefs(document)
+	}
+}
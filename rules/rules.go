@@ -6,73 +6,240 @@ import (
 	"github.com/shanejonas/openrpc-linter/functions"
 	"github.com/shanejonas/openrpc-linter/types"
 
-	"github.com/PaesslerAG/jsonpath"
 	"gopkg.in/yaml.v3"
 )
 
+// ExecuteRule runs a rule against the document in context and returns its
+// findings.
 func ExecuteRule(rule *types.Rule, context types.RuleFunctionContext) ([]types.RuleFunctionResult, error) {
+	return ExecuteRuleWithCache(rule, context, NewQueryCache())
+}
+
+// ExecuteRuleWithCache is ExecuteRule sharing JSONPath query results through
+// cache with other rules run against the same document.
+func ExecuteRuleWithCache(rule *types.Rule, context types.RuleFunctionContext, cache *QueryCache) ([]types.RuleFunctionResult, error) {
 
 	documentToUse := context.Document
-	if context.ResolvedDocument != nil {
+	useResolved := rule.Resolved == nil || *rule.Resolved
+	if useResolved && context.ResolvedDocument != nil {
 		documentToUse = context.ResolvedDocument
 	}
 
-	res, err := jsonpath.Get(rule.Given, documentToUse)
+	// Look up every function up front so a misconfigured action fails the
+	// whole rule before any results are produced.
+	ruleFuncs, err := lookupFunctions(rule.Then)
+	if err != nil {
+		return nil, err
+	}
+	whenFuncs, err := lookupFunctions(rule.When)
 	if err != nil {
-		return nil, fmt.Errorf("error getting JSON path: %w", err)
+		return nil, err
+	}
+	unlessFuncs, err := lookupFunctions(rule.Unless)
+	if err != nil {
+		return nil, err
 	}
 
-	if rule.Then != nil {
-		ruleFunc := functions.FunctionRegistry[rule.Then.Function]
-		if ruleFunc == nil {
-			return nil, fmt.Errorf("unknown function: %s", rule.Then.Function)
-		}
+	seen := make(map[string]bool)
 
-		if resArray, ok := res.([]interface{}); ok {
-			var allResults []types.RuleFunctionResult
+	givenContexts := make([]types.RuleFunctionContext, len(rule.Given))
+	givenNodes := make([][]matchedNode, len(rule.Given))
+	for i, given := range rule.Given {
+		candidates, err := cache.Query(given, documentToUse)
+		if err != nil {
+			return nil, fmt.Errorf("error getting JSON path: %w", err)
+		}
 
-			for i, item := range resArray {
-				var valueToValidate interface{}
+		givenContexts[i] = context
+		givenContexts[i].Given = given
 
-				if rule.Then.Field != "" {
-					if itemMap, ok := item.(map[string]interface{}); ok {
-						valueToValidate = itemMap[rule.Then.Field]
-					}
-				} else {
-					valueToValidate = item
+		for _, node := range matchedNodes(candidates, seen) {
+			if len(rule.When) > 0 {
+				pass, err := predicatesPass(rule.When, whenFuncs, node, givenContexts[i])
+				if err != nil {
+					return nil, err
+				}
+				if !pass {
+					continue
+				}
+			}
+			if len(rule.Unless) > 0 {
+				pass, err := predicatesPass(rule.Unless, unlessFuncs, node, givenContexts[i])
+				if err != nil {
+					return nil, err
 				}
+				if pass {
+					continue
+				}
+			}
+			givenNodes[i] = append(givenNodes[i], node)
+		}
+	}
 
-				itemContext := context
-				itemContext.ArrayIndex = &i
+	severity := rule.Severity
+	if severity == "" {
+		severity = types.SeverityError
+	}
 
-				results := ruleFunc.RunRule(valueToValidate, itemContext)
+	allResults := []types.RuleFunctionResult{}
+	for i := range rule.Then {
+		var actionResults []types.RuleFunctionResult
+		for g, nodes := range givenNodes {
+			actionContext := givenContexts[g]
+			actionContext.Action = &rule.Then[i]
 
-				for _, result := range results {
-					if result.Message != "" {
-						allResults = append(allResults, result)
-					}
-				}
+			results, err := executeAction(&rule.Then[i], ruleFuncs[i], nodes, actionContext)
+			if err != nil {
+				return nil, err
+			}
+			actionResults = append(actionResults, results...)
+		}
+
+		if useResolved && context.ResolvedDocument != nil {
+			actionResults = collapseRefResults(actionResults, context.RefOrigins)
+		}
+		for j := range actionResults {
+			if actionResults[j].Severity == "" {
+				actionResults[j].Severity = severity
 			}
+			if actionResults[j].Kind == "" {
+				actionResults[j].Kind = types.KindViolation
+			}
+		}
+		allResults = append(allResults, actionResults...)
+	}
 
-			return allResults, nil
-		} else {
-			var valueToValidate interface{}
+	return allResults, nil
+}
 
-			if rule.Then.Field != "" {
-				if resMap, ok := res.(map[string]interface{}); ok {
-					valueToValidate = resMap[rule.Then.Field]
-				}
-			} else {
-				valueToValidate = res
+func lookupFunctions(actions types.RuleActions) ([]types.RuleFunction, error) {
+	ruleFuncs := make([]types.RuleFunction, len(actions))
+	for i, action := range actions {
+		ruleFunc := functions.GetFunction(action.Function)
+		if ruleFunc == nil {
+			return nil, fmt.Errorf("unknown function: %s", action.Function)
+		}
+		ruleFuncs[i] = ruleFunc
+	}
+	return ruleFuncs, nil
+}
+
+// predicatesPass reports whether every `when` or `unless` action passes,
+// i.e. produces no results, for a matched node.
+func predicatesPass(actions types.RuleActions, ruleFuncs []types.RuleFunction, node matchedNode, context types.RuleFunctionContext) (bool, error) {
+	for i := range actions {
+		value, _ := actionValue(&actions[i], node)
+
+		predicateContext := context
+		predicateContext.Action = &actions[i]
+		predicateContext.Path = node.Path
+		predicateContext.ArrayIndex = node.Index
+
+		for _, result := range ruleFuncs[i].RunRule(value, predicateContext) {
+			if result.IsError() {
+				return false, functionError(&actions[i], result)
 			}
+			if result.Message != "" {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// functionError is the error for a rule function that could not check a
+// value, e.g. because its options are invalid. It fails the whole rule.
+func functionError(action *types.RuleAction, result types.RuleFunctionResult) error {
+	return fmt.Errorf("function %s: %s", action.Function, result.Message)
+}
 
-			results := ruleFunc.RunRule(valueToValidate, context)
+// actionValue returns the value an action checks for a matched node, along
+// with its path when the node's path is known.
+func actionValue(action *types.RuleAction, node matchedNode) (interface{}, []string) {
+	if action.Field == "" {
+		return node.Value, node.Path
+	}
+
+	var value interface{}
+	if nodeMap, ok := node.Value.(map[string]interface{}); ok {
+		value = nodeMap[action.Field]
+	}
+	if node.Path == nil {
+		return value, nil
+	}
+	return value, appendPath(node.Path, action.Field)
+}
+
+// matchedNode is a node matched by a `given` path. Index is the position of
+// the node in the match list when the path matched more than one node, and
+// Path is where the node lives in the document if it could be traced.
+type matchedNode struct {
+	Value interface{}
+	Index *int
+	Path  []string
+}
 
-			return results, nil
+// matchedNodes returns the nodes matched by a `given` path, skipping any
+// node whose path is already in seen. Nodes without a path cannot be told
+// apart and are always kept.
+func matchedNodes(candidates []matchedNode, seen map[string]bool) []matchedNode {
+	var nodes []matchedNode
+	for _, node := range candidates {
+		if node.Path != nil {
+			key := types.JSONPath(node.Path)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// executeAction runs a single `then` action against the nodes matched by the
+// rule's `given` path. Results for nodes covered by an x-lint-ignore for the
+// rule are kept but marked as suppressed. Paths into shared definitions are
+// those of the usage site, so this is decided for each usage separately.
+func executeAction(action *types.RuleAction, ruleFunc types.RuleFunction, nodes []matchedNode, context types.RuleFunctionContext) ([]types.RuleFunctionResult, error) {
+	var allResults []types.RuleFunctionResult
+
+	for _, node := range nodes {
+		valueToValidate, valuePath := actionValue(action, node)
+
+		nodeContext := context
+		nodeContext.Path = node.Path
+		nodeContext.ArrayIndex = node.Index
+
+		results := ruleFunc.RunRule(valueToValidate, nodeContext)
+
+		for _, result := range results {
+			if result.IsError() {
+				return nil, functionError(action, result)
+			}
+			if result.Message == "" {
+				continue
+			}
+			if result.RuleID == "" {
+				result.RuleID = context.RuleID
+			}
+			if len(result.Path) == 0 && valuePath != nil {
+				result.Path = valuePath
+			}
+			if context.Rule != nil && context.Rule.Message != "" {
+				result.Message = renderMessage(context.Rule.Message, messageVars(context.Rule, action, valueToValidate, result))
+			}
+			if len(result.Path) > 0 {
+				if reason, ok := ignoredBy(context.Document, result.Path, result.RuleID); ok {
+					result.Suppressed = true
+					result.SuppressionReason = reason
+				}
+			}
+			allResults = append(allResults, result)
 		}
 	}
 
-	return []types.RuleFunctionResult{}, nil
+	return allResults, nil
 }
 
 func GetFieldFromNode(node *yaml.Node, field string) *yaml.Node {
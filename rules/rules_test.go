@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -22,11 +23,11 @@ func TestExecuteRule(t *testing.T) {
 			name: "truthy rule with missing field",
 			rule: &types.Rule{
 				Description: "Test missing field",
-				Given:       "$.info",
-				Then: &types.RuleAction{
+				Given:       types.StringList{"$.info"},
+				Then: types.RuleActions{{
 					Field:    "description",
 					Function: "truthy",
-				},
+				}},
 			},
 			document: map[string]interface{}{
 				"info": map[string]interface{}{
@@ -42,11 +43,11 @@ func TestExecuteRule(t *testing.T) {
 			name: "truthy rule with present field",
 			rule: &types.Rule{
 				Description: "Test present field",
-				Given:       "$.info",
-				Then: &types.RuleAction{
+				Given:       types.StringList{"$.info"},
+				Then: types.RuleActions{{
 					Field:    "description",
 					Function: "truthy",
-				},
+				}},
 			},
 			document: map[string]interface{}{
 				"info": map[string]interface{}{
@@ -61,11 +62,11 @@ func TestExecuteRule(t *testing.T) {
 			name: "unknown function",
 			rule: &types.Rule{
 				Description: "Test unknown function",
-				Given:       "$.info",
-				Then: &types.RuleAction{
+				Given:       types.StringList{"$.info"},
+				Then: types.RuleActions{{
 					Field:    "title",
 					Function: "unknownFunction",
-				},
+				}},
 			},
 			document: map[string]interface{}{
 				"info": map[string]interface{}{
@@ -79,11 +80,11 @@ func TestExecuteRule(t *testing.T) {
 			name: "invalid jsonpath",
 			rule: &types.Rule{
 				Description: "Test invalid path",
-				Given:       "$.nonexistent",
-				Then: &types.RuleAction{
+				Given:       types.StringList{"$.nonexistent"},
+				Then: types.RuleActions{{
 					Field:    "title",
 					Function: "truthy",
-				},
+				}},
 			},
 			document: map[string]interface{}{
 				"info": map[string]interface{}{
@@ -131,6 +132,547 @@ func TestExecuteRule(t *testing.T) {
 	}
 }
 
+func TestExecuteRuleMultipleActions(t *testing.T) {
+	rulesYAML := `
+given: "$.methods[*]"
+then:
+  - field: "description"
+    function: "truthy"
+  - field: "summary"
+    function: "truthy"
+`
+	var rule types.Rule
+	if err := yaml.Unmarshal([]byte(rulesYAML), &rule); err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+	if len(rule.Then) != 2 {
+		t.Fatalf("Expected 2 actions, got %d", len(rule.Then))
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "foo"},
+		},
+	}
+
+	results, err := ExecuteRule(&rule, types.RuleFunctionContext{
+		Rule:     &rule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+
+	expected := []string{
+		"Missing required field 'description' at $.methods[0]",
+		"Missing required field 'summary' at $.methods[0]",
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(expected), len(results), results)
+	}
+	for i, msg := range expected {
+		if results[i].Message != msg {
+			t.Errorf("Expected result message %q, got %q", msg, results[i].Message)
+		}
+	}
+}
+
+func TestExecuteRuleMultipleGiven(t *testing.T) {
+	rulesYAML := `
+given:
+  - "$.methods[*]"
+  - "$.components.errors[*]"
+  - '$.methods[?(@.name == "foo")]'
+then:
+  field: "description"
+  function: "truthy"
+`
+	var rule types.Rule
+	if err := yaml.Unmarshal([]byte(rulesYAML), &rule); err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"name": "bar", "description": "Bar"},
+		},
+		"components": map[string]interface{}{
+			"errors": []interface{}{
+				map[string]interface{}{"code": 1},
+			},
+		},
+	}
+
+	results, err := ExecuteRule(&rule, types.RuleFunctionContext{
+		Rule:     &rule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+
+	expected := []string{
+		"Missing required field 'description' at $.methods[0]",
+		"Missing required field 'description' at $.components.errors[0]",
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(expected), len(results), results)
+	}
+	for i, msg := range expected {
+		if results[i].Message != msg {
+			t.Errorf("Expected result message %q, got %q", msg, results[i].Message)
+		}
+	}
+
+	// Overlapping paths to scalars report each value once
+	scalarRule := &types.Rule{
+		Given: types.StringList{"$.methods[*].name", "$.methods[1].name"},
+		Then: types.RuleActions{{
+			Function:        "pattern",
+			FunctionOptions: map[string]interface{}{"match": "^[a-z]+$"},
+		}},
+	}
+	document["methods"].([]interface{})[1].(map[string]interface{})["name"] = "Bar"
+
+	results, err = ExecuteRule(scalarRule, types.RuleFunctionContext{
+		Rule:     scalarRule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+	if len(results) != 1 || types.JSONPath(results[0].Path) != "$.methods[1].name" {
+		t.Errorf("Expected 1 result at $.methods[1].name, got %+v", results)
+	}
+}
+
+func TestExecuteRuleMessageTemplate(t *testing.T) {
+	rule := &types.Rule{
+		Description: "Methods must be documented",
+		Given:       types.StringList{"$.methods[*]"},
+		Message:     "{{description}}: {{property}} is missing at {{path}} (was {{value}}; {{error}})",
+		Then: types.RuleActions{{
+			Field:    "description",
+			Function: "truthy",
+		}},
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "foo", "description": ""},
+		},
+	}
+
+	results, err := ExecuteRule(rule, types.RuleFunctionContext{
+		Rule:     rule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d: %+v", len(results), results)
+	}
+
+	expectedMsg := "Methods must be documented: description is missing at $.methods[0].description (was ; Missing required field 'description' at $.methods[0])"
+	if results[0].Message != expectedMsg {
+		t.Errorf("Expected result message %q, got %q", expectedMsg, results[0].Message)
+	}
+
+	expectedPath := []string{"methods", "0", "description"}
+	if !reflect.DeepEqual(results[0].Path, expectedPath) {
+		t.Errorf("Expected result path %v, got %v", expectedPath, results[0].Path)
+	}
+}
+
+func TestExecuteRuleResolved(t *testing.T) {
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{
+				"params": []interface{}{
+					map[string]interface{}{"$ref": "#/components/contentDescriptors/Foo"},
+				},
+			},
+		},
+	}
+	resolvedDocument := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{
+				"params": []interface{}{
+					map[string]interface{}{"name": "foo"},
+				},
+			},
+		},
+	}
+
+	resolvedFalse := false
+	tests := []struct {
+		name          string
+		resolved      *bool
+		expectedCount int
+	}{
+		{name: "default uses resolved document", resolved: nil, expectedCount: 1},
+		{name: "resolved false uses raw document", resolved: &resolvedFalse, expectedCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &types.Rule{
+				Given:    types.StringList{"$.methods[*].params[*]"},
+				Resolved: tt.resolved,
+				Then: types.RuleActions{{
+					Field:    "$ref",
+					Function: "truthy",
+				}},
+			}
+
+			results, err := ExecuteRule(rule, types.RuleFunctionContext{
+				Rule:             rule,
+				RuleID:           "test-rule",
+				Document:         document,
+				ResolvedDocument: resolvedDocument,
+			})
+			if err != nil {
+				t.Fatalf("Expected success, but got error: %v", err)
+			}
+			if len(results) != tt.expectedCount {
+				t.Errorf("Expected %d results, got %d: %+v", tt.expectedCount, len(results), results)
+			}
+		})
+	}
+}
+
+func TestExecuteRuleIgnore(t *testing.T) {
+	rule := &types.Rule{
+		Given: types.StringList{"$.methods[*]"},
+		Then: types.RuleActions{{
+			Field:    "description",
+			Function: "truthy",
+		}},
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{
+				"name":          "legacy",
+				"x-lint-ignore": map[string]interface{}{"test-rule": "kept for compatibility"},
+			},
+			map[string]interface{}{
+				"name":          "other",
+				"x-lint-ignore": []interface{}{"another-rule"},
+			},
+		},
+	}
+
+	results, err := ExecuteRule(rule, types.RuleFunctionContext{
+		Rule:     rule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d: %+v", len(results), results)
+	}
+
+	if !results[0].Suppressed || results[0].SuppressionReason != "kept for compatibility" {
+		t.Errorf("Expected first result to be suppressed with reason, got %+v", results[0])
+	}
+	if results[1].Suppressed {
+		t.Errorf("Expected second result not to be suppressed, got %+v", results[1])
+	}
+}
+
+func TestExecuteRuleIgnoreSharedRef(t *testing.T) {
+	rule := &types.Rule{
+		Given: types.StringList{"$.methods[*].params[*].schema"},
+		Then: types.RuleActions{{
+			Field:    "title",
+			Function: "truthy",
+		}},
+	}
+
+	param := func() interface{} {
+		return map[string]interface{}{"name": "p", "schema": map[string]interface{}{"$ref": "#/components/schemas/Foo"}}
+	}
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "ignores", "x-lint-ignore": "test-rule", "params": []interface{}{param()}},
+			map[string]interface{}{"name": "checks", "params": []interface{}{param()}},
+			map[string]interface{}{"name": "alsoIgnores", "x-lint-ignore": "test-rule", "params": []interface{}{param()}},
+		},
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{"Foo": map[string]interface{}{"type": "string"}},
+		},
+	}
+
+	lint := func(document interface{}) []types.RuleFunctionResult {
+		t.Helper()
+		resolved, origins, err := ResolveRefs(document)
+		if err != nil {
+			t.Fatalf("ResolveRefs() returned error: %v", err)
+		}
+		results, err := ExecuteRule(rule, types.RuleFunctionContext{
+			Rule:             rule,
+			RuleID:           "test-rule",
+			Document:         document,
+			ResolvedDocument: resolved,
+			RefOrigins:       origins,
+		})
+		if err != nil {
+			t.Fatalf("Expected success, but got error: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 collapsed result, got %d: %+v", len(results), results)
+		}
+		return results
+	}
+
+	// Ignored in some usages: still reported for the others
+	result := lint(document)[0]
+	if result.Suppressed {
+		t.Errorf("Expected the finding not to be suppressed while a usage does not ignore it, got %+v", result)
+	}
+	if len(result.Related) != 1 || types.JSONPath(result.Related[0]) != "$.methods[1].params[0].schema.title" {
+		t.Errorf("Expected the usage that does not ignore the finding as related, got %v", result.Related)
+	}
+	if len(result.IgnoredAt) != 2 || types.JSONPath(result.IgnoredAt[0]) != "$.methods[0].params[0].schema.title" ||
+		types.JSONPath(result.IgnoredAt[1]) != "$.methods[2].params[0].schema.title" {
+		t.Errorf("Expected the usages that ignore the finding in IgnoredAt, got %v", result.IgnoredAt)
+	}
+
+	// Ignored in every usage: suppressed
+	document["methods"].([]interface{})[1].(map[string]interface{})["x-lint-ignore"] = "test-rule"
+	result = lint(document)[0]
+	if !result.Suppressed || len(result.IgnoredAt) != 0 {
+		t.Errorf("Expected the finding to be suppressed when every usage ignores it, got %+v", result)
+	}
+}
+
+func TestExecuteRuleSharedRefMessage(t *testing.T) {
+	method := func(name string) interface{} {
+		return map[string]interface{}{
+			"name":   name,
+			"params": []interface{}{map[string]interface{}{"$ref": "#/components/contentDescriptors/P"}},
+		}
+	}
+	components := map[string]interface{}{
+		"contentDescriptors": map[string]interface{}{
+			"P": map[string]interface{}{"name": "p", "schema": map[string]interface{}{"$ref": "#/components/schemas/S"}},
+		},
+		"schemas": map[string]interface{}{"S": map[string]interface{}{"type": "string"}},
+	}
+
+	lint := func(rule *types.Rule, methods ...interface{}) types.RuleFunctionResult {
+		t.Helper()
+		document := map[string]interface{}{"methods": methods, "components": components}
+		resolved, origins, err := ResolveRefs(document)
+		if err != nil {
+			t.Fatalf("ResolveRefs() returned error: %v", err)
+		}
+		results, err := ExecuteRule(rule, types.RuleFunctionContext{
+			Rule:             rule,
+			RuleID:           "test-rule",
+			Document:         document,
+			ResolvedDocument: resolved,
+			RefOrigins:       origins,
+		})
+		if err != nil {
+			t.Fatalf("Expected success, but got error: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 collapsed result, got %d: %+v", len(results), results)
+		}
+		return results[0]
+	}
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name: "function message",
+			want: "Missing required field 'title' at $.components.schemas.S",
+		},
+		{
+			name:    "path placeholder",
+			message: "{{property}} is missing at {{path}}",
+			want:    "title is missing at $.components.schemas.S.title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &types.Rule{
+				Given:   types.StringList{"$.methods[*].params[*].schema"},
+				Message: tt.message,
+				Then:    types.RuleActions{{Field: "title", Function: "truthy"}},
+			}
+
+			// The message names the definition, not whichever usage the
+			// finding was first found at, so it does not change when the
+			// first usage moves.
+			result := lint(rule, method("foo"), method("bar"))
+			if result.Message != tt.want {
+				t.Errorf("Expected message %q, got %q", tt.want, result.Message)
+			}
+			unrelated := map[string]interface{}{"name": "baz", "params": []interface{}{}}
+			if moved := lint(rule, unrelated, method("bar"), method("foo")); types.Fingerprint(moved) != types.Fingerprint(result) {
+				t.Errorf("Expected the same fingerprint after moving the first usage, got %q and %q", result.Message, moved.Message)
+			}
+		})
+	}
+}
+
+func TestExecuteRuleScalarGiven(t *testing.T) {
+	rulesYAML := `
+given: "$.methods[*].name"
+then:
+  function: "pattern"
+  functionOptions:
+    match: "^[a-z]+$"
+`
+	var rule types.Rule
+	if err := yaml.Unmarshal([]byte(rulesYAML), &rule); err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "Bad"},
+			map[string]interface{}{"name": "good"},
+			map[string]interface{}{"name": "Legacy", "x-lint-ignore": "test-rule"},
+		},
+	}
+
+	results, err := ExecuteRule(&rule, types.RuleFunctionContext{
+		Rule:     &rule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d: %+v", len(results), results)
+	}
+
+	if path := types.JSONPath(results[0].Path); path != "$.methods[0].name" || results[0].Suppressed {
+		t.Errorf("Expected unsuppressed result at $.methods[0].name, got %s: %+v", path, results[0])
+	}
+	if path := types.JSONPath(results[1].Path); path != "$.methods[2].name" || !results[1].Suppressed {
+		t.Errorf("Expected suppressed result at $.methods[2].name, got %s: %+v", path, results[1])
+	}
+}
+
+func TestExecuteRuleWhenUnless(t *testing.T) {
+	rulesYAML := `
+given: "$.methods[*]"
+when:
+  - field: "paramStructure"
+    function: "truthy"
+  - field: "paramStructure"
+    function: "pattern"
+    functionOptions:
+      match: "^by-name$"
+unless:
+  field: "deprecated"
+  function: "truthy"
+then:
+  field: "description"
+  function: "truthy"
+`
+	var rule types.Rule
+	if err := yaml.Unmarshal([]byte(rulesYAML), &rule); err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "byName", "paramStructure": "by-name"},
+			map[string]interface{}{"name": "byPosition", "paramStructure": "by-position"},
+			map[string]interface{}{"name": "unset"},
+			map[string]interface{}{"name": "deprecated", "paramStructure": "by-name", "deprecated": true},
+		},
+	}
+
+	results, err := ExecuteRule(&rule, types.RuleFunctionContext{
+		Rule:     &rule,
+		RuleID:   "test-rule",
+		Document: document,
+	})
+	if err != nil {
+		t.Fatalf("Expected success, but got error: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d: %+v", len(results), results)
+	}
+	expectedMsg := "Missing required field 'description' at $.methods[0]"
+	if results[0].Message != expectedMsg {
+		t.Errorf("Expected result message %q, got %q", expectedMsg, results[0].Message)
+	}
+}
+
+func TestExecuteRuleInvalidPattern(t *testing.T) {
+	invalid := types.RuleActions{{
+		Field:           "name",
+		Function:        "pattern",
+		FunctionOptions: map[string]interface{}{"match": "(unclosed"},
+	}}
+	valid := types.RuleActions{{Field: "description", Function: "truthy"}}
+
+	document := map[string]interface{}{
+		"methods": []interface{}{
+			map[string]interface{}{"name": "foo"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		rule *types.Rule
+	}{
+		{name: "then", rule: &types.Rule{Given: types.StringList{"$.methods[*]"}, Then: invalid}},
+		{name: "when", rule: &types.Rule{Given: types.StringList{"$.methods[*]"}, When: invalid, Then: valid}},
+		{name: "unless", rule: &types.Rule{Given: types.StringList{"$.methods[*]"}, Unless: invalid, Then: valid}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := ExecuteRule(tt.rule, types.RuleFunctionContext{
+				Rule:     tt.rule,
+				RuleID:   "test-rule",
+				Document: document,
+			})
+			if err == nil || !strings.Contains(err.Error(), "function pattern: Invalid match pattern '(unclosed'") {
+				t.Errorf("Expected the invalid pattern to fail the rule, got results %+v, err %v", results, err)
+			}
+		})
+	}
+}
+
+func TestRuleActionsSingleObject(t *testing.T) {
+	var rule types.Rule
+	rulesYAML := `
+then:
+  field: "description"
+  function: "truthy"
+`
+	if err := yaml.Unmarshal([]byte(rulesYAML), &rule); err != nil {
+		t.Fatalf("Failed to parse rule: %v", err)
+	}
+	if len(rule.Then) != 1 || rule.Then[0].Field != "description" {
+		t.Errorf("Expected a single description action, got %+v", rule.Then)
+	}
+}
+
 func TestGetFieldFromNode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -198,11 +740,11 @@ func TestGetFieldFromNode(t *testing.T) {
 func BenchmarkExecuteRule(b *testing.B) {
 	rule := &types.Rule{
 		Description: "Benchmark rule",
-		Given:       "$.info",
-		Then: &types.RuleAction{
+		Given:       types.StringList{"$.info"},
+		Then: types.RuleActions{{
 			Field:    "description",
 			Function: "truthy",
-		},
+		}},
 	}
 
 	document := map[string]interface{}{
@@ -1,42 +1,286 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/santhosh-tekuri/jsonschema/v6"
+	"gopkg.in/yaml.v3"
+)
+
+// Ruleset is the contents of a rules file.
+type Ruleset struct {
+	Description string                `json:"description,omitempty"`
+	Extends     StringList            `json:"extends,omitempty"`
+	Aliases     map[string]StringList `json:"aliases,omitempty"`
+	Rules       map[string]Rule       `json:"rules"`
+	Overrides   []Override            `json:"overrides,omitempty"`
+	Reporters   []ReporterConfig      `json:"reporters,omitempty"` // Reports to write when none are given on the command line
+}
+
+// ReporterConfig selects a report format and the file to write it to. An
+// empty Output writes the report to standard output.
+type ReporterConfig struct {
+	Format string `json:"format"`
+	Output string `json:"output,omitempty"`
+}
+
+// Override changes the severity of rules for documents matching Files and,
+// when Paths is set, only for findings inside the subtrees Paths select.
+// When several overrides apply to a finding, the last one wins.
+type Override struct {
+	Files StringList        `json:"files"`
+	Paths StringList        `json:"paths,omitempty"`
+	Rules map[string]string `json:"rules"` // Rule ID -> severity
+}
+
+const (
+	SeverityError = "error"
+	SeverityWarn  = "warn"
+	SeverityInfo  = "info"
+	SeverityHint  = "hint"
+	SeverityOff   = "off"
 )
 
+// ValidSeverity reports whether severity is one a rule may be set to.
+func ValidSeverity(severity string) bool {
+	switch severity {
+	case SeverityError, SeverityWarn, SeverityInfo, SeverityHint, SeverityOff:
+		return true
+	}
+	return false
+}
+
 type Rule struct {
-	Description string      `json:"description"`
-	Given       string      `json:"given,omitempty"`
-	Then        *RuleAction `json:"then,omitempty"`
-	Extends     interface{} `json:"extends,omitempty"`
+	Description   string      `json:"description"`
+	Given         StringList  `json:"given,omitempty"`
+	Then          RuleActions `json:"then,omitempty"`
+	When          RuleActions `json:"when,omitempty"`          // Only check matched nodes for which every action passes
+	Unless        RuleActions `json:"unless,omitempty"`        // Skip matched nodes for which every action passes
+	Severity      string      `json:"severity,omitempty"`      // Defaults to error
+	Formats       StringList  `json:"formats,omitempty"`       // Only run against documents of these formats, e.g. openrpc-1.3
+	Message       string      `json:"message,omitempty"`       // Optional template overriding the function's message
+	Documentation string      `json:"documentation,omitempty"` // URL of the rule's documentation
+	Resolved      *bool       `json:"resolved,omitempty"`      // Set to false to run against the document with $refs intact
+	Extends       interface{} `json:"extends,omitempty"`
 }
 
 type RuleAction struct {
 	Field           string                 `json:"field,omitempty"`
 	Function        string                 `json:"function,omitempty"`
-	FunctionOptions map[string]interface{} `json:"functionOptions,omitempty"`
+	FunctionOptions map[string]interface{} `json:"functionOptions,omitempty" yaml:"functionOptions,omitempty"`
 }
 
+// RuleActions is the list of actions run against the nodes matched by a rule.
+// In a rules file `then` may be written as a single action or a list of actions.
+type RuleActions []RuleAction
+
+func (a *RuleActions) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var actions []RuleAction
+		if err := value.Decode(&actions); err != nil {
+			return err
+		}
+		*a = actions
+		return nil
+	}
+
+	var action RuleAction
+	if err := value.Decode(&action); err != nil {
+		return err
+	}
+	*a = RuleActions{action}
+	return nil
+}
+
+func (a *RuleActions) UnmarshalJSON(data []byte) error {
+	var actions []RuleAction
+	if err := json.Unmarshal(data, &actions); err == nil {
+		*a = actions
+		return nil
+	}
+
+	var action RuleAction
+	if err := json.Unmarshal(data, &action); err != nil {
+		return err
+	}
+	*a = RuleActions{action}
+	return nil
+}
+
+// StringList is a list of strings that may be written in a rules file as
+// either a single string or a list of strings.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var items []string
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	}
+
+	var item string
+	if err := value.Decode(&item); err != nil {
+		return err
+	}
+	*l = StringList{item}
+	return nil
+}
+
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var items []string
+	if err := json.Unmarshal(data, &items); err == nil {
+		*l = items
+		return nil
+	}
+
+	var item string
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	*l = StringList{item}
+	return nil
+}
+
+// Result kinds. A violation is a problem found in the document; an error
+// means the rule itself could not run, e.g. a bad JSONPath, an unknown
+// function, invalid function options or a rule function that panicked.
+const (
+	KindViolation = "violation"
+	KindError     = "error"
+)
+
 type RuleFunctionResult struct {
-	Message string   `json:"message,omitempty"`
-	Path    []string `json:"path,omitempty"`
-	RuleID  string   `json:"ruleId,omitempty"`
+	Message  string     `json:"message,omitempty"`
+	Path     []string   `json:"path,omitempty"`
+	RuleID   string     `json:"ruleId,omitempty"`
+	Severity string     `json:"severity,omitempty"`
+	Kind     string     `json:"kind,omitempty"`    // KindViolation or KindError; empty is treated as a violation
+	Related  [][]string `json:"related,omitempty"` // Other paths where the same finding occurs, e.g. usages of a shared $ref
+	File     string     `json:"file,omitempty"`    // Document the result was found in
+	Range    *Range     `json:"range,omitempty"`   // Where Path, or its nearest existing ancestor, is in File
+
+	Fingerprint string `json:"fingerprint,omitempty"` // Identifies the finding across runs; see Fingerprint
+
+	Suppressed        bool       `json:"suppressed,omitempty"`        // Silenced by an x-lint-ignore in the document
+	SuppressionReason string     `json:"suppressionReason,omitempty"` // Reason given alongside the x-lint-ignore, if any
+	IgnoredAt         [][]string `json:"ignoredAt,omitempty"`         // Usages of a shared $ref that silence the finding, when others do not
+	Baselined         bool       `json:"baselined,omitempty"`         // Accepted by the baseline file
+}
+
+// Position is a 1-based line and column in a source file. Columns count
+// characters, not bytes.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Range is a span of a source file. End is exclusive.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// IsError reports whether the result is a rule execution error rather than
+// a violation in the document.
+func (r RuleFunctionResult) IsError() bool {
+	return r.Kind == KindError
+}
+
+// JSONPath formats a result path as a JSONPath expression, e.g.
+// ["methods", "0", "name"] becomes $.methods[0].name.
+func JSONPath(path []string) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, segment := range path {
+		if _, err := strconv.Atoi(segment); err == nil {
+			sb.WriteString("[" + segment + "]")
+		} else if identifierPattern.MatchString(segment) {
+			sb.WriteString("." + segment)
+		} else {
+			sb.WriteString("['" + strings.ReplaceAll(segment, "'", "\\'") + "']")
+		}
+	}
+	return sb.String()
+}
+
+// Fingerprint identifies a finding by its rule ID, file, path and message,
+// so the same finding gets the same fingerprint from one run to the next.
+// It does not depend on where in the file the finding is, so it survives
+// unrelated edits that shift lines. The file is taken as FingerprintFile
+// gives it, and whitespace in the message is collapsed.
+func Fingerprint(result RuleFunctionResult) string {
+	file := ""
+	if result.File != "" {
+		file = FingerprintFile(result.File)
+	}
+	message := strings.Join(strings.Fields(result.Message), " ")
+
+	hash := sha256.New()
+	for _, part := range []string{result.RuleID, file, JSONPath(result.Path), message} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
+// FingerprintFile returns file relative to the working directory, with
+// forward slashes, so a file gets the same fingerprint whether it was named
+// by a relative or an absolute path, and on any machine the repository is
+// checked out on. A file that cannot be made relative is used as given.
+// Reports read against the repository, such as SARIF and GitLab Code
+// Quality, name files the same way.
+func FingerprintFile(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil {
+				file = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
 type RuleFunctionSchema struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description,omitempty"`
 	Schema      map[string]interface{} `json:"schema,omitempty"`
 }
 
+// RefOrigin records a $ref that was resolved: where it is used and the path
+// of the definition it points to.
+type RefOrigin struct {
+	Usage      []string `json:"usage"`
+	Definition []string `json:"definition"`
+}
+
 type RuleFunctionContext struct {
-	Rule             *Rule       `json:"rule"`
-	RuleID           string      `json:"ruleId"`
-	Document         interface{} `json:"document"`         // Original document with potential $refs
-	ResolvedDocument interface{} `json:"resolvedDocument"` // Document with all $refs resolved
-	ArrayIndex       *int        `json:"arrayIndex,omitempty"`
+	Rule             *Rule                `json:"rule"`
+	RuleID           string               `json:"ruleId"`
+	Given            string               `json:"given,omitempty"`      // The `given` path currently being evaluated
+	Action           *RuleAction          `json:"action,omitempty"`     // The `then` action currently being run
+	Document         interface{}          `json:"document"`             // Original document with potential $refs
+	ResolvedDocument interface{}          `json:"resolvedDocument"`     // Document with all $refs resolved
+	RefOrigins       map[string]RefOrigin `json:"refOrigins,omitempty"` // Each $ref resolved in ResolvedDocument, keyed by the JSONPath of its usage site
+	Path             []string             `json:"path,omitempty"`       // Where the matched node is in the document, if it could be traced
+	ArrayIndex       *int                 `json:"arrayIndex,omitempty"`
 }
 
+// RuleFunction checks a value matched by a rule. Rules may run concurrently,
+// so implementations must be safe for concurrent use and must not modify the
+// value or the documents in the context. A result of KindError, e.g. for
+// invalid function options, fails the whole rule.
 type RuleFunction interface {
 	RunRule(value interface{}, context RuleFunctionContext) []RuleFunctionResult
 	GetSchema() *jsonschema.Schema